@@ -2,49 +2,229 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 )
 
 // Generic authentication errors.
 var (
-	UnauthenticatedError = errors.New("unauthenticated, login before continuing")
-	SessionExpiredError  = errors.New("session expired, re-login before continuing")
+	UnauthenticatedError   = errors.New("unauthenticated, login before continuing")
+	SessionExpiredError    = errors.New("session expired, re-login before continuing")
+	TwoFactorRequiredError = errors.New("two-factor authentication required, login with a code")
 )
 
 // loginInfo is the representation of the body of a login request.
 type loginInfo struct {
 	Username string `json:"username,omitempty"`
 	Password string `json:"password,omitempty"`
+	// The two-factor authentication code used by UniFi OS controllers.
+	Token string `json:"token,omitempty"`
+	// The two-factor authentication code used by other controllers.
+	Ubic2faToken string `json:"ubic_2fa_token,omitempty"`
+}
+
+// The maximum duration of a re-authentication shared by multiple goroutines.
+const reloginTimeout = 30 * time.Second
+
+// reloginCall is a re-authentication in progress, it is shared by all goroutines that require a
+// new session at the same time so only a single login request is made.
+type reloginCall struct {
+	// Closed once the re-authentication has finished.
+	done chan struct{}
+	// The result of the re-authentication, only valid after done has been closed.
+	err error
 }
 
 // Login authenticates the user at the UniFi controller using the given username and password and
-// saves the received cookie and CSRF token. It returns an error if the login fails.
+// saves the received cookie and CSRF token. It returns an error if the login fails, if the user
+// requires two-factor authentication the error matches [TwoFactorRequiredError] (using
+// [errors.Is]).
 func (controller *Controller) Login(username string, password string) error {
+	return controller.LoginWithContext(context.Background(), username, password)
+}
+
+// LoginWithContext is the same as [Controller.Login] but uses the given context for the login
+// request.
+func (controller *Controller) LoginWithContext(
+	ctx context.Context,
+	username string,
+	password string,
+) error {
+	return controller.storeAndLogin(
+		ctx,
+		&StaticCredentialProvider{Username: username, Password: password},
+		"",
+	)
+}
+
+// LoginWithTwoFactorCode authenticates the user at the UniFi controller using the given username,
+// password and two-factor authentication code (e.g. generated by an authenticator app) and saves
+// the received cookie and CSRF token. As the code can only be used once, the [Controller] can not
+// re-authenticate when the session expires, use [Controller.LoginWithTotpSecret] if this is
+// required. It returns an error if the login fails.
+func (controller *Controller) LoginWithTwoFactorCode(
+	username string,
+	password string,
+	code string,
+) error {
+	return controller.LoginWithTwoFactorCodeWithContext(
+		context.Background(),
+		username,
+		password,
+		code,
+	)
+}
+
+// LoginWithTwoFactorCodeWithContext is the same as [Controller.LoginWithTwoFactorCode] but uses
+// the given context for the login request.
+func (controller *Controller) LoginWithTwoFactorCodeWithContext(
+	ctx context.Context,
+	username string,
+	password string,
+	code string,
+) error {
+	return controller.storeAndLogin(
+		ctx,
+		&StaticCredentialProvider{Username: username, Password: password},
+		code,
+	)
+}
+
+// LoginWithTotpSecret authenticates the user at the UniFi controller using the given username,
+// password and a two-factor authentication code generated using the given TOTP secret (base32
+// encoded, as shown when setting up two-factor authentication) and saves the received cookie and
+// CSRF token. A new code is generated every time re-authentication is required.
+// It returns an error if the secret is invalid or the login fails.
+func (controller *Controller) LoginWithTotpSecret(
+	username string,
+	password string,
+	totpSecret string,
+) error {
+	return controller.LoginWithTotpSecretWithContext(
+		context.Background(),
+		username,
+		password,
+		totpSecret,
+	)
+}
+
+// LoginWithTotpSecretWithContext is the same as [Controller.LoginWithTotpSecret] but uses the
+// given context for the login request.
+func (controller *Controller) LoginWithTotpSecretWithContext(
+	ctx context.Context,
+	username string,
+	password string,
+	totpSecret string,
+) error {
+	// Verify the secret is valid before storing it.
+	_, err := GenerateTotpCode(totpSecret, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return controller.storeAndLogin(
+		ctx,
+		&StaticCredentialProvider{Username: username, Password: password, TotpSecret: totpSecret},
+		"",
+	)
+}
+
+// Authenticate authenticates at the UniFi controller using the credentials returned by the
+// [CredentialProvider] of the [Controller] (see [Controller.SetCredentialProvider]) and saves the
+// received cookie and CSRF token. It returns an [UnauthenticatedError] if no credential provider
+// is set or an error if the credentials could not be retrieved or the login fails.
+func (controller *Controller) Authenticate() error {
+	return controller.AuthenticateWithContext(context.Background())
+}
+
+// AuthenticateWithContext is the same as [Controller.Authenticate] but uses the given context for
+// retrieving the credentials and the login request.
+func (controller *Controller) AuthenticateWithContext(ctx context.Context) error {
+	controller.sessionMutex.RLock()
+	provider := controller.credentialProvider
+	controller.sessionMutex.RUnlock()
+
+	return controller.loginUsingProvider(ctx, provider, "")
+}
+
+// Stores the given credential provider for re-authentication and logs in using its credentials
+// and the given two-factor authentication code (if not empty). It returns an error if the login
+// fails.
+func (controller *Controller) storeAndLogin(
+	ctx context.Context,
+	provider CredentialProvider,
+	twoFactorCode string,
+) error {
+	controller.sessionMutex.Lock()
+	controller.credentialProvider = provider
+	controller.sessionMutex.Unlock()
+
+	return controller.loginUsingProvider(ctx, provider, twoFactorCode)
+}
+
+// Logs in using the credentials returned by the given credential provider and the given
+// two-factor authentication code, if the code is empty and the credentials contain a TOTP secret
+// a code is generated. It returns an error if the credentials could not be retrieved or the login
+// fails.
+func (controller *Controller) loginUsingProvider(
+	ctx context.Context,
+	provider CredentialProvider,
+	twoFactorCode string,
+) error {
+	if provider == nil {
+		return UnauthenticatedError
+	}
+
+	credentials, err := provider.Credentials(ctx)
+	if err != nil {
+		return fmt.Errorf("retrieving credentials failed: %w", err)
+	}
+
+	info := loginInfo{
+		Username: credentials.Username,
+		Password: credentials.Password,
+		Token:    twoFactorCode,
+	}
+	if info.Token == "" && credentials.TotpSecret != "" {
+		info.Token, err = GenerateTotpCode(credentials.TotpSecret, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	return controller.login(ctx, info)
+}
+
+// Sends a login request using the given login info and saves the received cookie and CSRF token.
+// It returns an error if the login fails.
+func (controller *Controller) login(ctx context.Context, info loginInfo) error {
 	var endpointUrl string
 
 	switch controller.controllerType {
-	case "UDM-Pro":
+	case ControllerTypeUnifiOs:
 		endpointUrl = fmt.Sprintf("%s/api/auth/login", controller.baseUrl)
 	default:
 		endpointUrl = fmt.Sprintf("%s/api/login", controller.baseUrl)
 	}
 
-	controller.loginInfo = loginInfo{
-		Username: username,
-		Password: password,
+	// Only UniFi OS controllers use the token field for the two-factor authentication code.
+	if info.Token != "" && controller.controllerType != ControllerTypeUnifiOs {
+		info.Ubic2faToken = info.Token
+		info.Token = ""
 	}
 
-	byteArray, err := json.Marshal(controller.loginInfo)
+	byteArray, err := json.Marshal(info)
 	if err != nil {
 		return err
 	}
 
 	payload := bytes.NewBuffer(byteArray)
-	req, err := http.NewRequest(`POST`, endpointUrl, payload)
+	req, err := http.NewRequestWithContext(ctx, `POST`, endpointUrl, payload)
 	if err != nil {
 		return err
 	}
@@ -55,93 +235,194 @@ func (controller *Controller) Login(username string, password string) error {
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 
 	if res.StatusCode != 200 {
-		return errors.New(fmt.Sprintf("login failed with response code %d", res.StatusCode))
+		responseBodyByteArray, _ := io.ReadAll(res.Body)
+		return fmt.Errorf(
+			"login failed: %w",
+			newResponseError(res.StatusCode, responseBodyByteArray),
+		)
 	}
 
+	var sessionCookie *http.Cookie
 	cookies := res.Cookies()
 	for _, cookie := range cookies {
 		if cookie.Name == "TOKEN" {
-			controller.cookie = cookie
+			sessionCookie = cookie
 			break
 		}
 	}
 
-	if controller.cookie == nil {
+	if sessionCookie == nil {
 		return errors.New("failed to extract 'TOKEN' cookie from cookies")
 	}
 
-	controller.csrfToken = res.Header.Get(`X-CSRF-token`)
-	if controller.csrfToken == "" {
+	csrfToken := res.Header.Get(`X-CSRF-token`)
+	if csrfToken == "" {
 		return errors.New("failed to extract CSRF token from response header")
 	}
 
+	controller.sessionMutex.Lock()
+	controller.cookie = sessionCookie
+	controller.csrfToken = csrfToken
+	controller.sessionGeneration++
+	controller.sessionMutex.Unlock()
+
 	return nil
 }
 
 // Logout invalidates the current session credentials (cookie and CSRF token) and clears the
-// user credentials. It returns an error if the logout fails.
+// user credentials (credential provider). It returns an error if the logout fails.
 func (controller *Controller) Logout() error {
+	return controller.LogoutWithContext(context.Background())
+}
+
+// LogoutWithContext is the same as [Controller.Logout] but uses the given context for the logout
+// request.
+func (controller *Controller) LogoutWithContext(ctx context.Context) error {
 	var endpointUrl string
 	switch controller.controllerType {
-	case "UDM-Pro":
+	case ControllerTypeUnifiOs:
 		endpointUrl = fmt.Sprintf("%s/api/auth/logout", controller.baseUrl)
 	default:
 		endpointUrl = fmt.Sprintf("%s/api/logout", controller.baseUrl)
 	}
 
-	res, err := controller.execute(http.MethodPost, endpointUrl, nil, nil)
+	_, err := controller.execute(ctx, http.MethodPost, endpointUrl, nil, nil)
 	if err != nil {
-		return err
-	}
-
-	if res.StatusCode != 200 {
-		return errors.New(fmt.Sprintf("logout failed with response code %d", res.StatusCode))
+		return fmt.Errorf("logout failed: %w", err)
 	}
 
 	// Clear cookie, CSRF token and user credentials.
+	controller.sessionMutex.Lock()
 	controller.cookie = nil
 	controller.csrfToken = ""
-	controller.loginInfo.Username = ""
-	controller.loginInfo.Password = ""
+	controller.credentialProvider = nil
+	controller.sessionGeneration++
+	controller.sessionMutex.Unlock()
 
 	return nil
 }
 
-// AuthorizeRequest adds the authorization cookie and CSRF token to the given http request.
-// If the current session has expired re-authentication is attempted.
+// AuthorizeRequest adds the authorization cookie and CSRF token to the given http request, or the
+// `X-API-KEY` header if the [Controller] uses an API key.
+// If the current session has expired re-authentication is attempted, concurrent requests share a
+// single re-authentication and each stops waiting for it when the context of its request is done.
+// Sessions invalidated by the UniFi controller itself can not be detected here, requests made by
+// the [Controller] handle these by re-authenticating when the request is rejected.
 // It returns an [UnauthenticatedError] if the [Controller] has not received authentication, a login
 // error can also be returned if re-authentication was attempted and the login failed.
 func (controller *Controller) AuthorizeRequest(req *http.Request) error {
-	err := controller.AssertAuthenticated()
+	_, err := controller.authorizeRequest(req)
+	return err
+}
+
+// Implements [Controller.AuthorizeRequest] and returns the generation of the session used to
+// authorize the request.
+func (controller *Controller) authorizeRequest(req *http.Request) (uint64, error) {
+	controller.sessionMutex.RLock()
+	if controller.apiKey != "" {
+		req.Header.Set("X-API-KEY", controller.apiKey)
+		controller.sessionMutex.RUnlock()
+		return 0, nil
+	}
+	err := controller.assertAuthenticated()
+	generation := controller.sessionGeneration
+	controller.sessionMutex.RUnlock()
 
 	if err != nil && errors.Is(err, SessionExpiredError) {
-		err = controller.Login(
-			controller.loginInfo.Username,
-			controller.loginInfo.Password,
-		)
+		err = controller.reauthenticate(req.Context(), generation)
+	}
+	if err != nil {
+		return generation, err
+	}
+
+	controller.sessionMutex.RLock()
+	defer controller.sessionMutex.RUnlock()
+
+	// The session could have been cleared by a concurrent logout.
+	if controller.cookie == nil {
+		return controller.sessionGeneration, UnauthenticatedError
 	}
 
-	if err == nil {
-		req.AddCookie(controller.cookie)
-		req.Header.Set("X-CSRF-Token", controller.csrfToken)
+	req.AddCookie(controller.cookie)
+	req.Header.Set("X-CSRF-Token", controller.csrfToken)
+	return controller.sessionGeneration, nil
+}
+
+// Re-authenticates using the stored credential provider, replacing the session with the given
+// generation. If the session was already replaced in the meantime nothing is done, if another
+// goroutine is already re-authenticating its result is awaited instead of sending another login
+// request. The login itself is not cancelled by the given context (it is limited by a fixed
+// timeout instead), the context only determines how long this goroutine waits for it.
+// It returns an error if the re-authentication failed or the context was cancelled while waiting.
+func (controller *Controller) reauthenticate(ctx context.Context, generation uint64) error {
+	controller.sessionMutex.Lock()
+	if controller.sessionGeneration != generation {
+		controller.sessionMutex.Unlock()
 		return nil
 	}
 
-	return err
+	call := controller.relogin
+	if call == nil {
+		call = &reloginCall{done: make(chan struct{})}
+		controller.relogin = call
+		provider := controller.credentialProvider
+
+		// The shared login is detached from the context of the goroutine starting it, otherwise
+		// its cancellation would make the re-authentication fail for all waiting goroutines.
+		loginCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), reloginTimeout)
+		go func() {
+			defer cancel()
+			call.err = controller.loginUsingProvider(loginCtx, provider, "")
+
+			controller.sessionMutex.Lock()
+			controller.relogin = nil
+			controller.sessionMutex.Unlock()
+			close(call.done)
+		}()
+	}
+	controller.sessionMutex.Unlock()
+
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // AssertAuthenticated asserts that the [Controller] has received authentication and that the current
 // session is still valid. Based on the [Controller] state an [UnauthenticatedError],
-// [SessionExpiredError] or no error will be returned.
+// [SessionExpiredError] or no error will be returned. A session cookie without expiration is
+// considered valid as only the UniFi controller knows when it expires, the same goes for an API
+// key.
 func (controller *Controller) AssertAuthenticated() error {
+	controller.sessionMutex.RLock()
+	defer controller.sessionMutex.RUnlock()
+
+	return controller.assertAuthenticated()
+}
+
+// Implements [Controller.AssertAuthenticated], the caller must hold the session mutex.
+func (controller *Controller) assertAuthenticated() error {
+	// An API key does not expire and can not be verified without making a request.
+	if controller.apiKey != "" {
+		return nil
+	}
+
 	if controller.cookie == nil || controller.csrfToken == "" {
 		return UnauthenticatedError
 	}
 
+	// A cookie without expiration is valid until the UniFi controller rejects it.
+	if controller.cookie.Expires.IsZero() {
+		return nil
+	}
+
 	// Mark session as expired 1 minute before expiration to account for clock skew.
-	currentTime := time.Now().Add(-1 * time.Minute)
+	currentTime := time.Now().Add(1 * time.Minute)
 	if controller.cookie.Expires.Before(currentTime) {
 		return SessionExpiredError
 	}
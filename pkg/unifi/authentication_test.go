@@ -0,0 +1,155 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testServer is a fake (classic) UniFi controller counting the login and API requests it receives.
+type testServer struct {
+	*httptest.Server
+	// The number of login requests received.
+	logins atomic.Int32
+	// The number of API requests (not login requests) received.
+	requests atomic.Int32
+	// Called before a login request is answered, if set.
+	onLogin func()
+	// Handles the API requests (not login requests), responds with an empty data array if nil.
+	handler http.HandlerFunc
+}
+
+// Starts a new [testServer] which is closed when the test finishes.
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	server := &testServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/login", func(w http.ResponseWriter, r *http.Request) {
+		server.logins.Add(1)
+		if server.onLogin != nil {
+			server.onLogin()
+		}
+		http.SetCookie(w, &http.Cookie{Name: "TOKEN", Value: "session-token"})
+		w.Header().Set("X-CSRF-Token", "csrf-token")
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		server.requests.Add(1)
+		if server.handler != nil {
+			server.handler(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	})
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// Builds a [Controller] for the given [testServer] using static credentials, the session of the
+// controller has already expired.
+func newExpiredTestController(t *testing.T, server *testServer) *Controller {
+	t.Helper()
+
+	builder := ControllerBuilder{}
+	controller, err := builder.
+		SetBaseUrl(server.URL).
+		SetControllerType(ControllerTypeClassic).
+		SetCredentialProvider(&StaticCredentialProvider{Username: "user", Password: "pass"}).
+		Build()
+	if err != nil {
+		t.Fatalf("building controller failed: %s", err)
+	}
+
+	controller.cookie = &http.Cookie{
+		Name:    "TOKEN",
+		Value:   "expired-token",
+		Expires: time.Now().Add(-time.Hour),
+	}
+	controller.csrfToken = "expired-csrf-token"
+
+	return controller
+}
+
+func TestReauthenticateConcurrentRequestsShareLogin(t *testing.T) {
+	server := newTestServer(t)
+	// Keep the login in progress long enough for all goroutines to wait for it.
+	server.onLogin = func() { time.Sleep(50 * time.Millisecond) }
+	controller := newExpiredTestController(t, server)
+	site := controller.CreateDefaultSite()
+
+	const goroutines = 20
+	var wg sync.WaitGroup
+	errs := make(chan error, goroutines)
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := site.GetAllFirewallGroups()
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("request failed: %s", err)
+		}
+	}
+	if logins := server.logins.Load(); logins != 1 {
+		t.Errorf("expected 1 login, got %d", logins)
+	}
+	if requests := server.requests.Load(); requests != goroutines {
+		t.Errorf("expected %d requests, got %d", goroutines, requests)
+	}
+}
+
+func TestReauthenticateIgnoresCancellationOfFirstCaller(t *testing.T) {
+	server := newTestServer(t)
+	loginStarted := make(chan struct{})
+	releaseLogin := make(chan struct{})
+	server.onLogin = func() {
+		close(loginStarted)
+		<-releaseLogin
+	}
+	controller := newExpiredTestController(t, server)
+	site := controller.CreateDefaultSite()
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := site.GetAllFirewallGroupsWithContext(firstCtx)
+		firstErr <- err
+	}()
+	<-loginStarted
+
+	secondErr := make(chan error, 1)
+	go func() {
+		_, err := site.GetAllFirewallGroupsWithContext(context.Background())
+		secondErr <- err
+	}()
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected first request to be cancelled, got %v", err)
+	}
+
+	close(releaseLogin)
+	if err := <-secondErr; err != nil {
+		t.Errorf("expected second request to succeed, got %s", err)
+	}
+	if logins := server.logins.Load(); logins != 1 {
+		t.Errorf("expected 1 login, got %d", logins)
+	}
+	if err := controller.AssertAuthenticated(); err != nil {
+		t.Errorf("expected controller to be authenticated, got %s", err)
+	}
+}
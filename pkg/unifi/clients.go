@@ -0,0 +1,423 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ClientResponse is the representation of a response of a client request.
+type ClientResponse struct {
+	Meta Meta                 `json:"meta"`
+	Data []ClientResponseData `json:"data"`
+}
+
+// ClientResponseData is the representation of the data inside the data array of the
+// [ClientResponse]. It contains either [Client] or [DataValidationError] based on whether the
+// request succeeded.
+type ClientResponseData struct {
+	*Client
+	*DataValidationError
+}
+
+// The `rest/user` endpoint managing the known clients of a [Site].
+var clientResource = restResource[Client, ClientResponse]{
+	path:       "rest/user",
+	name:       "client",
+	pluralName: "clients",
+}
+
+// Client is the representation of a client (station) of a site. Statistics (e.g. Ip, Uptime) are
+// only included for connected clients.
+type Client struct {
+	// The client ID.
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this client.
+	SiteId string `json:"site_id,omitempty"`
+	// The MAC address of the client.
+	Mac string `json:"mac,omitempty"`
+	// The hostname reported by the client.
+	Hostname string `json:"hostname,omitempty"`
+	// The name (alias) of the client set in the UniFi controller.
+	Name string `json:"name,omitempty"`
+	// The note of the client.
+	Note string `json:"note,omitempty"`
+	// Indicates whether the client has a note.
+	Noted bool `json:"noted,omitempty"`
+	// The vendor of the client based on the OUI of its MAC address.
+	Oui string `json:"oui,omitempty"`
+	// The current IP address of the client.
+	Ip string `json:"ip,omitempty"`
+	// Indicates whether the client uses the fixed IP address FixedIp.
+	UseFixedIp bool `json:"use_fixedip,omitempty"`
+	// The fixed IP address of the client, used when UseFixedIp is true.
+	FixedIp string `json:"fixed_ip,omitempty"`
+	// The ID of the network (see [Network]) the client is connected to.
+	NetworkId string `json:"network_id,omitempty"`
+	// The name of the network the client is connected to.
+	Network string `json:"network,omitempty"`
+	// The ID of the user group applying the bandwidth limits of the client.
+	UserGroupId string `json:"usergroup_id,omitempty"`
+	// Indicates whether the client is connected using a cable.
+	IsWired bool `json:"is_wired,omitempty"`
+	// Indicates whether the client is a guest.
+	IsGuest bool `json:"is_guest,omitempty"`
+	// Indicates whether the client is blocked.
+	Blocked bool `json:"blocked,omitempty"`
+	// The SSID of the WLAN the client is connected to.
+	Essid string `json:"essid,omitempty"`
+	// The MAC address of the access point the client is connected to.
+	ApMac string `json:"ap_mac,omitempty"`
+	// The MAC address of the switch the client is connected to.
+	SwMac string `json:"sw_mac,omitempty"`
+	// The port of the switch the client is connected to.
+	SwPort int `json:"sw_port,omitempty"`
+	// The signal strength of the client in dBm.
+	Signal int `json:"signal,omitempty"`
+	// The time (unix timestamp) at which the client was first seen.
+	FirstSeen int64 `json:"first_seen,omitempty"`
+	// The time (unix timestamp) at which the client was last seen.
+	LastSeen int64 `json:"last_seen,omitempty"`
+	// The time the client is connected in seconds.
+	Uptime int64 `json:"uptime,omitempty"`
+	// The number of bytes sent to the client.
+	TxBytes int64 `json:"tx_bytes,omitempty"`
+	// The number of bytes received from the client.
+	RxBytes int64 `json:"rx_bytes,omitempty"`
+}
+
+// stationManagerCommand is the representation of the body of a station manager command.
+type stationManagerCommand struct {
+	Cmd  string   `json:"cmd"`
+	Mac  string   `json:"mac,omitempty"`
+	Macs []string `json:"macs,omitempty"`
+}
+
+// clientHistoryRequest is the representation of the body of a client history request.
+type clientHistoryRequest struct {
+	Type   string `json:"type"`
+	Conn   string `json:"conn"`
+	Within int    `json:"within"`
+}
+
+// GetAllActiveClients returns all clients currently connected to this [Site].
+// It will return an error if it fails to fetch the clients.
+func (site *Site) GetAllActiveClients() (ClientResponse, error) {
+	return site.GetAllActiveClientsWithContext(context.Background())
+}
+
+// GetAllActiveClientsWithContext is the same as [Site.GetAllActiveClients] but uses the given
+// context for the request.
+func (site *Site) GetAllActiveClientsWithContext(ctx context.Context) (ClientResponse, error) {
+	endpointUrl := site.createEndpointUrl("stat/sta", "")
+	responseData := ClientResponse{}
+
+	_, err := site.controller.execute(ctx, http.MethodGet, endpointUrl, nil, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("retreiving active clients failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// GetAllKnownClients returns all clients known by this [Site] which have a configuration (e.g. a
+// name, note or fixed IP address) or have been blocked.
+// It will return an error if it fails to fetch the clients.
+func (site *Site) GetAllKnownClients() (ClientResponse, error) {
+	return site.GetAllKnownClientsWithContext(context.Background())
+}
+
+// GetAllKnownClientsWithContext is the same as [Site.GetAllKnownClients] but uses the given
+// context for the request.
+func (site *Site) GetAllKnownClientsWithContext(ctx context.Context) (ClientResponse, error) {
+	return clientResource.getAll(ctx, site)
+}
+
+// GetClientHistory returns all clients that have been connected to this [Site] within the given
+// duration (rounded up to hours).
+// It will return an error if it fails to fetch the clients.
+func (site *Site) GetClientHistory(within time.Duration) (ClientResponse, error) {
+	return site.GetClientHistoryWithContext(context.Background(), within)
+}
+
+// GetClientHistoryWithContext is the same as [Site.GetClientHistory] but uses the given context
+// for the request.
+func (site *Site) GetClientHistoryWithContext(
+	ctx context.Context,
+	within time.Duration,
+) (ClientResponse, error) {
+	endpointUrl := site.createEndpointUrl("stat/alluser", "")
+	responseData := ClientResponse{}
+	request := clientHistoryRequest{
+		Type:   "all",
+		Conn:   "all",
+		Within: int(math.Ceil(within.Hours())),
+	}
+
+	_, err := site.controller.execute(ctx, http.MethodPost, endpointUrl, request, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("retreiving client history failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// GetActiveClientByMac returns the client with the given MAC address (case-insensitive) currently
+// connected to this [Site].
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no such client is
+// connected or an error if it fails to fetch the clients.
+func (site *Site) GetActiveClientByMac(mac string) (*Client, error) {
+	return site.GetActiveClientByMacWithContext(context.Background(), mac)
+}
+
+// GetActiveClientByMacWithContext is the same as [Site.GetActiveClientByMac] but uses the given
+// context for the request.
+func (site *Site) GetActiveClientByMacWithContext(
+	ctx context.Context,
+	mac string,
+) (*Client, error) {
+	description := fmt.Sprintf("MAC address %q", mac)
+	return site.findActiveClient(ctx, description, func(client *Client) bool {
+		return strings.EqualFold(client.Mac, mac)
+	})
+}
+
+// GetActiveClientByHostname returns the client with the given hostname (case-insensitive)
+// currently connected to this [Site].
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no such client is
+// connected or an error if it fails to fetch the clients.
+func (site *Site) GetActiveClientByHostname(hostname string) (*Client, error) {
+	return site.GetActiveClientByHostnameWithContext(context.Background(), hostname)
+}
+
+// GetActiveClientByHostnameWithContext is the same as [Site.GetActiveClientByHostname] but uses
+// the given context for the request.
+func (site *Site) GetActiveClientByHostnameWithContext(
+	ctx context.Context,
+	hostname string,
+) (*Client, error) {
+	description := fmt.Sprintf("hostname %q", hostname)
+	return site.findActiveClient(ctx, description, func(client *Client) bool {
+		return strings.EqualFold(client.Hostname, hostname)
+	})
+}
+
+// GetActiveClientByIp returns the client with the given IP address currently connected to this
+// [Site].
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no such client is
+// connected or an error if it fails to fetch the clients.
+func (site *Site) GetActiveClientByIp(ip string) (*Client, error) {
+	return site.GetActiveClientByIpWithContext(context.Background(), ip)
+}
+
+// GetActiveClientByIpWithContext is the same as [Site.GetActiveClientByIp] but uses the given
+// context for the request.
+func (site *Site) GetActiveClientByIpWithContext(ctx context.Context, ip string) (*Client, error) {
+	return site.findActiveClient(ctx, fmt.Sprintf("IP address %q", ip), func(client *Client) bool {
+		return client.Ip == ip
+	})
+}
+
+// Returns the first client currently connected to the [Site] for which matches returns true.
+// It will return an error matching [NotFoundError] (using [errors.Is]), using the given
+// description of the client, if no such client is connected or an error if it fails to fetch the
+// clients.
+func (site *Site) findActiveClient(
+	ctx context.Context,
+	description string,
+	matches func(client *Client) bool,
+) (*Client, error) {
+	response, err := site.GetAllActiveClientsWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, responseData := range response.Data {
+		if responseData.Client != nil && matches(responseData.Client) {
+			return responseData.Client, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no active client with %s", NotFoundError, description)
+}
+
+// BlockClient blocks the client with the given MAC address from connecting to this [Site].
+// It will return an error if blocking the client failed.
+func (site *Site) BlockClient(mac string) (ClientResponse, error) {
+	return site.BlockClientWithContext(context.Background(), mac)
+}
+
+// BlockClientWithContext is the same as [Site.BlockClient] but uses the given context for the
+// request.
+func (site *Site) BlockClientWithContext(ctx context.Context, mac string) (ClientResponse, error) {
+	return site.executeStationManagerCommand(
+		ctx,
+		stationManagerCommand{Cmd: "block-sta", Mac: mac},
+		"blocking client",
+	)
+}
+
+// UnblockClient unblocks the client with the given MAC address, allowing it to connect to this
+// [Site] again. It will return an error if unblocking the client failed.
+func (site *Site) UnblockClient(mac string) (ClientResponse, error) {
+	return site.UnblockClientWithContext(context.Background(), mac)
+}
+
+// UnblockClientWithContext is the same as [Site.UnblockClient] but uses the given context for the
+// request.
+func (site *Site) UnblockClientWithContext(
+	ctx context.Context,
+	mac string,
+) (ClientResponse, error) {
+	return site.executeStationManagerCommand(
+		ctx,
+		stationManagerCommand{Cmd: "unblock-sta", Mac: mac},
+		"unblocking client",
+	)
+}
+
+// KickClient disconnects the client with the given MAC address from this [Site], the client is
+// allowed to reconnect. It will return an error if disconnecting the client failed.
+func (site *Site) KickClient(mac string) (ClientResponse, error) {
+	return site.KickClientWithContext(context.Background(), mac)
+}
+
+// KickClientWithContext is the same as [Site.KickClient] but uses the given context for the
+// request.
+func (site *Site) KickClientWithContext(ctx context.Context, mac string) (ClientResponse, error) {
+	return site.executeStationManagerCommand(
+		ctx,
+		stationManagerCommand{Cmd: "kick-sta", Mac: mac},
+		"kicking client",
+	)
+}
+
+// ForgetClients removes the clients with the given MAC addresses and their history from this
+// [Site]. It will return an error if forgetting the clients failed.
+func (site *Site) ForgetClients(macs []string) (ClientResponse, error) {
+	return site.ForgetClientsWithContext(context.Background(), macs)
+}
+
+// ForgetClientsWithContext is the same as [Site.ForgetClients] but uses the given context for the
+// request.
+func (site *Site) ForgetClientsWithContext(
+	ctx context.Context,
+	macs []string,
+) (ClientResponse, error) {
+	return site.executeStationManagerCommand(
+		ctx,
+		stationManagerCommand{Cmd: "forget-sta", Macs: macs},
+		"forgetting clients",
+	)
+}
+
+// Sends the given command to the station manager of the [Site] and parses the response.
+// It will return an error describing the given operation if the command fails.
+func (site *Site) executeStationManagerCommand(
+	ctx context.Context,
+	command any,
+	operation string,
+) (ClientResponse, error) {
+	responseData := ClientResponse{}
+
+	err := site.executeCommand(ctx, "stamgr", command, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("%s failed: %w", operation, err)
+	}
+
+	return responseData, nil
+}
+
+// SetClientName sets the name (alias) of the known client linked to the given ID and this [Site],
+// an empty name removes the name. It will return an error if the update of the client failed.
+func (site *Site) SetClientName(id string, name string) (ClientResponse, error) {
+	return site.SetClientNameWithContext(context.Background(), id, name)
+}
+
+// SetClientNameWithContext is the same as [Site.SetClientName] but uses the given context for the
+// request.
+func (site *Site) SetClientNameWithContext(
+	ctx context.Context,
+	id string,
+	name string,
+) (ClientResponse, error) {
+	return clientResource.updateFields(ctx, site, id, map[string]any{"name": name})
+}
+
+// SetClientNote sets the note of the known client linked to the given ID and this [Site], an
+// empty note removes the note. It will return an error if the update of the client failed.
+func (site *Site) SetClientNote(id string, note string) (ClientResponse, error) {
+	return site.SetClientNoteWithContext(context.Background(), id, note)
+}
+
+// SetClientNoteWithContext is the same as [Site.SetClientNote] but uses the given context for the
+// request.
+func (site *Site) SetClientNoteWithContext(
+	ctx context.Context,
+	id string,
+	note string,
+) (ClientResponse, error) {
+	return clientResource.updateFields(
+		ctx,
+		site,
+		id,
+		map[string]any{"note": note, "noted": note != ""},
+	)
+}
+
+// SetClientUserGroup links the known client linked to the given ID and this [Site] to the user
+// group linked to the given user group ID, which applies its bandwidth limits to the client.
+// It will return an error if the update of the client failed.
+func (site *Site) SetClientUserGroup(id string, userGroupId string) (ClientResponse, error) {
+	return site.SetClientUserGroupWithContext(context.Background(), id, userGroupId)
+}
+
+// SetClientUserGroupWithContext is the same as [Site.SetClientUserGroup] but uses the given
+// context for the request.
+func (site *Site) SetClientUserGroupWithContext(
+	ctx context.Context,
+	id string,
+	userGroupId string,
+) (ClientResponse, error) {
+	return clientResource.updateFields(ctx, site, id, map[string]any{"usergroup_id": userGroupId})
+}
+
+// SetClientFixedIp assigns the given fixed IP address in the network linked to the given network
+// ID (see [Network]) to the known client linked to the given ID and this [Site].
+// It will return an error if the update of the client failed.
+func (site *Site) SetClientFixedIp(id string, networkId string, ip string) (ClientResponse, error) {
+	return site.SetClientFixedIpWithContext(context.Background(), id, networkId, ip)
+}
+
+// SetClientFixedIpWithContext is the same as [Site.SetClientFixedIp] but uses the given context
+// for the request.
+func (site *Site) SetClientFixedIpWithContext(
+	ctx context.Context,
+	id string,
+	networkId string,
+	ip string,
+) (ClientResponse, error) {
+	return clientResource.updateFields(ctx, site, id, map[string]any{
+		"use_fixedip": true,
+		"network_id":  networkId,
+		"fixed_ip":    ip,
+	})
+}
+
+// RemoveClientFixedIp removes the fixed IP address of the known client linked to the given ID and
+// this [Site]. It will return an error if the update of the client failed.
+func (site *Site) RemoveClientFixedIp(id string) (ClientResponse, error) {
+	return site.RemoveClientFixedIpWithContext(context.Background(), id)
+}
+
+// RemoveClientFixedIpWithContext is the same as [Site.RemoveClientFixedIp] but uses the given
+// context for the request.
+func (site *Site) RemoveClientFixedIpWithContext(
+	ctx context.Context,
+	id string,
+) (ClientResponse, error) {
+	return clientResource.updateFields(ctx, site, id, map[string]any{"use_fixedip": false})
+}
@@ -10,17 +10,22 @@ type Meta struct {
 	RuleIndex int `json:"rule_index,omitempty"`
 	// An error message describing what went wrong.
 	Msg string `json:"msg,omitempty"`
+	// The validation error if the request data failed validation.
+	ValidationError *ValidationDetails `json:"validationError,omitempty"`
+}
+
+// ValidationDetails describes which field of the request data failed validation.
+type ValidationDetails struct {
+	// The field on which the validation failed.
+	Field string `json:"field,omitempty"`
+	// The expected pattern the field should adhere to.
+	Pattern string `json:"pattern,omitempty"`
 }
 
 // DataValidationError is the representation of an error in the data array of a request response.
 type DataValidationError struct {
 	// ValidationError indicates an error occurred when trying to validate a field.
-	ValidationError struct {
-		// The field on which the validation failed.
-		Field string `json:"field,omitempty"`
-		// The expected pattern the field should adhere to.
-		Pattern string `json:"pattern,omitempty"`
-	} `json:"validationError,omitempty"`
+	ValidationError ValidationDetails `json:"validationError,omitempty"`
 	// The response code indicating the response status.
 	Rc string `json:"rc,omitempty"`
 	// An error message describing what went wrong.
@@ -1,20 +1,43 @@
 package unifi
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
 // A ControllerBuilder helps to build a [Controller].
 type ControllerBuilder struct {
-	baseUrl             string
-	controllerType      string
-	requestTimeout      time.Duration
-	skipTLSVerification bool
+	apiKey                 string
+	baseUrl                string
+	caBundle               []byte
+	certificateFingerprint string
+	clientCertificate      *tls.Certificate
+	controllerType         ControllerType
+	credentialProvider     CredentialProvider
+	httpClient             *http.Client
+	minTlsVersion          uint16
+	requestTimeout         time.Duration
+	retryPolicy            RetryPolicy
+	roundTripper           http.RoundTripper
+	skipTLSVerification    bool
+}
+
+// SetApiKey sets the API key used to authenticate requests (default not set), when set no login is
+// required. API keys are only supported by UniFi OS controllers and can be created in the UniFi
+// Network application under `Settings > Control Plane > Integrations`.
+func (builder *ControllerBuilder) SetApiKey(apiKey string) *ControllerBuilder {
+	builder.apiKey = apiKey
+	return builder
 }
 
 // SetBaseUrl sets the URL at which the UniFi controller is reachable.
@@ -23,19 +46,88 @@ func (builder *ControllerBuilder) SetBaseUrl(baseUrl string) *ControllerBuilder
 	return builder
 }
 
+// SetCaBundle sets the PEM encoded CA certificate(s) used to verify the certificate of the UniFi
+// controller instead of the system CA certificates (default not set).
+func (builder *ControllerBuilder) SetCaBundle(pemCertificates []byte) *ControllerBuilder {
+	builder.caBundle = pemCertificates
+	return builder
+}
+
+// SetCertificateFingerprint pins the certificate of the UniFi controller to the certificate with
+// the given SHA-256 fingerprint (hex encoded, colons are ignored), e.g. for a self-signed
+// certificate (default not set). When set, the certificate is not verified using CA certificates
+// unless a CA bundle is set as well.
+func (builder *ControllerBuilder) SetCertificateFingerprint(fingerprint string) *ControllerBuilder {
+	builder.certificateFingerprint = fingerprint
+	return builder
+}
+
+// SetClientCertificate sets the certificate presented to the UniFi controller (or a proxy in front
+// of it) when it requests a client certificate (default not set).
+func (builder *ControllerBuilder) SetClientCertificate(
+	certificate tls.Certificate,
+) *ControllerBuilder {
+	builder.clientCertificate = &certificate
+	return builder
+}
+
 // SetControllerType sets the type of UniFi controller (some controllers use different endpoints,
-// not set uses default endpoints).
-func (builder *ControllerBuilder) SetControllerType(controllerType string) *ControllerBuilder {
+// default [ControllerTypeClassic]). Known UniFi OS controller models (e.g. "UDM-Pro") are accepted
+// as well, use [ControllerTypeAuto] to detect the type when the [Controller] is built.
+func (builder *ControllerBuilder) SetControllerType(
+	controllerType ControllerType,
+) *ControllerBuilder {
 	builder.controllerType = controllerType
 	return builder
 }
 
+// SetCredentialProvider sets the provider of the user credentials used by
+// [Controller.Authenticate] and to re-authenticate when the session expires (default not set).
+func (builder *ControllerBuilder) SetCredentialProvider(
+	provider CredentialProvider,
+) *ControllerBuilder {
+	builder.credentialProvider = provider
+	return builder
+}
+
+// SetHttpClient sets the http client used to make the requests (default a new client is created).
+// The request timeout is applied to a copy of the client if set, TLS settings can not be combined
+// with a custom http client and must be configured on the client itself.
+func (builder *ControllerBuilder) SetHttpClient(client *http.Client) *ControllerBuilder {
+	builder.httpClient = client
+	return builder
+}
+
+// SetMinTlsVersion sets the minimum TLS version used to connect to the UniFi controller, e.g.
+// [tls.VersionTLS12] (default the Go default minimum version).
+func (builder *ControllerBuilder) SetMinTlsVersion(version uint16) *ControllerBuilder {
+	builder.minTlsVersion = version
+	return builder
+}
+
+// SetRoundTripper sets the round tripper (transport) used by the http client to make the requests
+// (default a new transport is created), e.g. for instrumentation or proxies. TLS settings can not
+// be combined with a custom round tripper and must be configured on the round tripper itself.
+func (builder *ControllerBuilder) SetRoundTripper(
+	roundTripper http.RoundTripper,
+) *ControllerBuilder {
+	builder.roundTripper = roundTripper
+	return builder
+}
+
 // SetRequestTimout sets the timeout to use when making http requests (default no timeout).
 func (builder *ControllerBuilder) SetRequestTimout(timeout time.Duration) *ControllerBuilder {
 	builder.requestTimeout = timeout
 	return builder
 }
 
+// SetRetryPolicy sets the policy used to retry requests that failed due to a transient error
+// (default no retries), see [DefaultRetryPolicy] for sensible defaults.
+func (builder *ControllerBuilder) SetRetryPolicy(policy RetryPolicy) *ControllerBuilder {
+	builder.retryPolicy = policy
+	return builder
+}
+
 // SetTlsVerification indicates whether TLS verification should be used (default true).
 func (builder *ControllerBuilder) SetTlsVerification(verificationOn bool) *ControllerBuilder {
 	builder.skipTLSVerification = !verificationOn
@@ -43,8 +135,15 @@ func (builder *ControllerBuilder) SetTlsVerification(verificationOn bool) *Contr
 }
 
 // Build builds the [Controller] and returns a reference to it.
-// It will return an error if any of the currently set parameters are invalid.
+// It will return an error if any of the currently set parameters are invalid or if the controller
+// type should be detected and the detection failed.
 func (builder *ControllerBuilder) Build() (*Controller, error) {
+	return builder.BuildWithContext(context.Background())
+}
+
+// BuildWithContext is the same as [ControllerBuilder.Build] but uses the given context for the
+// requests to detect the controller type (if required).
+func (builder *ControllerBuilder) BuildWithContext(ctx context.Context) (*Controller, error) {
 	_, err := url.ParseRequestURI(builder.baseUrl)
 	if err != nil {
 		var urlError *url.Error
@@ -61,21 +160,143 @@ func (builder *ControllerBuilder) Build() (*Controller, error) {
 		return nil, errors.New("request timout can not be smaller than 0 (no timeout)")
 	}
 
-	httpTransport := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: builder.skipTLSVerification},
+	err = builder.controllerType.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = builder.retryPolicy.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, httpTransport, err := builder.buildHttpClient()
+	if err != nil {
+		return nil, err
 	}
 
+	controller := &Controller{
+		apiKey:                builder.apiKey,
+		baseUrl:               builder.baseUrl,
+		controllerType:        builder.controllerType.normalize(),
+		credentialProvider:    builder.credentialProvider,
+		httpClient:            httpClient,
+		httpTransport:         httpTransport,
+		certificatePinnedOnly: builder.certificateFingerprint != "" && builder.caBundle == nil,
+		retryPolicy:           builder.retryPolicy,
+	}
+
+	if controller.controllerType == ControllerTypeAuto {
+		_, err = controller.DetectControllerTypeWithContext(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return controller, nil
+}
+
+// Builds the http client based on the currently set parameters, the transport is only returned
+// if it was created by the builder. It will return an error if the parameters are invalid.
+func (builder *ControllerBuilder) buildHttpClient() (*http.Client, *http.Transport, error) {
+	if builder.httpClient != nil && builder.roundTripper != nil {
+		return nil, nil, errors.New("a http client and round tripper can not both be set")
+	}
+
+	customTls := builder.skipTLSVerification ||
+		builder.caBundle != nil ||
+		builder.certificateFingerprint != "" ||
+		builder.clientCertificate != nil ||
+		builder.minTlsVersion != 0
+	if customTls && (builder.httpClient != nil || builder.roundTripper != nil) {
+		return nil, nil, errors.New(
+			"TLS settings can not be combined with a custom http client or round tripper",
+		)
+	}
+
+	if builder.httpClient != nil {
+		httpClient := *builder.httpClient
+		if builder.requestTimeout != 0 {
+			httpClient.Timeout = builder.requestTimeout
+		}
+		return &httpClient, nil, nil
+	}
+
+	if builder.roundTripper != nil {
+		httpClient := &http.Client{
+			Timeout:   builder.requestTimeout,
+			Transport: builder.roundTripper,
+		}
+		return httpClient, nil, nil
+	}
+
+	tlsConfig, err := builder.buildTlsConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	httpTransport := http.DefaultTransport.(*http.Transport).Clone()
+	httpTransport.TLSClientConfig = tlsConfig
+
 	httpClient := &http.Client{
 		Timeout:   builder.requestTimeout,
 		Transport: httpTransport,
 	}
 
-	controller := &Controller{
-		baseUrl:        builder.baseUrl,
-		controllerType: builder.controllerType,
-		httpClient:     httpClient,
-		httpTransport:  httpTransport,
+	return httpClient, httpTransport, nil
+}
+
+// Builds the TLS config based on the currently set parameters.
+// It will return an error if any of the TLS parameters are invalid.
+func (builder *ControllerBuilder) buildTlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: builder.skipTLSVerification,
+		MinVersion:         builder.minTlsVersion,
 	}
 
-	return controller, nil
+	if builder.minTlsVersion != 0 &&
+		(builder.minTlsVersion < tls.VersionTLS10 || builder.minTlsVersion > tls.VersionTLS13) {
+		return nil, fmt.Errorf("unsupported minimum TLS version 0x%04x", builder.minTlsVersion)
+	}
+
+	if builder.caBundle != nil {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(builder.caBundle) {
+			return nil, errors.New("CA bundle does not contain any valid PEM certificate")
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if builder.clientCertificate != nil {
+		tlsConfig.Certificates = []tls.Certificate{*builder.clientCertificate}
+	}
+
+	if builder.certificateFingerprint != "" {
+		fingerprint, err := hex.DecodeString(
+			strings.ReplaceAll(builder.certificateFingerprint, ":", ""),
+		)
+		if err != nil || len(fingerprint) != sha256.Size {
+			return nil, errors.New("certificate fingerprint must be a hex encoded SHA-256 hash")
+		}
+
+		// Without a CA bundle the pinned certificate replaces the CA verification.
+		if builder.caBundle == nil {
+			tlsConfig.InsecureSkipVerify = true
+		}
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			if len(state.PeerCertificates) == 0 {
+				return errors.New("UniFi controller did not present a certificate")
+			}
+			actual := sha256.Sum256(state.PeerCertificates[0].Raw)
+			if !bytes.Equal(actual[:], fingerprint) {
+				return fmt.Errorf(
+					"UniFi controller certificate fingerprint %x does not match pinned fingerprint",
+					actual,
+				)
+			}
+			return nil
+		}
+	}
+
+	return tlsConfig, nil
 }
@@ -0,0 +1,66 @@
+package unifi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// Starts a new TLS server with a self-signed certificate responding with an empty data array and
+// returns it together with the SHA-256 fingerprint of its certificate.
+func newPinnedTestServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	fingerprint := sha256.Sum256(server.Certificate().Raw)
+	return server, hex.EncodeToString(fingerprint[:])
+}
+
+// Builds a [Controller] for the given server using an API key and the given pinned fingerprint.
+func newPinnedTestController(t *testing.T, serverUrl string, fingerprint string) *Controller {
+	t.Helper()
+
+	builder := ControllerBuilder{}
+	controller, err := builder.
+		SetBaseUrl(serverUrl).
+		SetControllerType(ControllerTypeClassic).
+		SetApiKey("api-key").
+		SetCertificateFingerprint(fingerprint).
+		Build()
+	if err != nil {
+		t.Fatalf("building controller failed: %s", err)
+	}
+	return controller
+}
+
+func TestCertificateFingerprintSurvivesTlsVerification(t *testing.T) {
+	server, fingerprint := newPinnedTestServer(t)
+	controller := newPinnedTestController(t, server.URL, fingerprint)
+
+	// The self-signed certificate can only be verified using the pinned fingerprint.
+	controller.SetTlsVerification(true)
+
+	_, err := controller.CreateDefaultSite().GetAllFirewallGroups()
+	if err != nil {
+		t.Errorf("request to pinned controller failed: %s", err)
+	}
+}
+
+func TestCertificateFingerprintMismatch(t *testing.T) {
+	server, _ := newPinnedTestServer(t)
+	otherFingerprint := sha256.Sum256([]byte("other certificate"))
+	controller := newPinnedTestController(t, server.URL, hex.EncodeToString(otherFingerprint[:]))
+
+	controller.SetTlsVerification(false)
+
+	_, err := controller.CreateDefaultSite().GetAllFirewallGroups()
+	if err == nil {
+		t.Error("expected request with mismatching fingerprint to fail")
+	}
+}
@@ -0,0 +1,134 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ControllerType is the type of UniFi controller, it determines which endpoints are used.
+type ControllerType string
+
+// The supported types of UniFi controller.
+const (
+	// ControllerTypeClassic is a UniFi Network application that does not run on UniFi OS, e.g. a
+	// self-hosted UniFi Network application or a Cloud Key Gen1 (default).
+	ControllerTypeClassic ControllerType = "classic"
+	// ControllerTypeUnifiOs is a UniFi Network application running on UniFi OS, e.g. a UDM,
+	// UDM-Pro, UDM-SE, UDR, UCG-Ultra or Cloud Key Gen2(+).
+	ControllerTypeUnifiOs ControllerType = "unifi-os"
+	// ControllerTypeAuto detects the type of UniFi controller when the [Controller] is built, see
+	// [Controller.DetectControllerType].
+	ControllerTypeAuto ControllerType = "auto"
+)
+
+// The models of UniFi controllers running UniFi OS, these are accepted as [ControllerType] for
+// compatibility and are treated as [ControllerTypeUnifiOs].
+var unifiOsModels = map[ControllerType]bool{
+	"UDM":         true,
+	"UDM-Pro":     true,
+	"UDM-Pro-Max": true,
+	"UDM-SE":      true,
+	"UDR":         true,
+	"UDW":         true,
+	"UCG-Ultra":   true,
+	"UCG-Max":     true,
+	"UCK-G2":      true,
+	"UCK-G2-Plus": true,
+	"UX":          true,
+	"EFG":         true,
+}
+
+// Returns the normalized type of controller, known UniFi OS controller models are normalized to
+// [ControllerTypeUnifiOs] and an empty type to [ControllerTypeClassic].
+func (controllerType ControllerType) normalize() ControllerType {
+	if controllerType == "" {
+		return ControllerTypeClassic
+	}
+	if unifiOsModels[controllerType] {
+		return ControllerTypeUnifiOs
+	}
+	return controllerType
+}
+
+// Verifies the type of controller is supported.
+// It returns an error if the (normalized) type of controller is unknown.
+func (controllerType ControllerType) validate() error {
+	switch controllerType.normalize() {
+	case ControllerTypeClassic, ControllerTypeUnifiOs, ControllerTypeAuto:
+		return nil
+	default:
+		return fmt.Errorf("unknown controller type %q", string(controllerType))
+	}
+}
+
+// DetectControllerType probes the UniFi controller to detect its type, updates the type of the
+// [Controller] accordingly and returns the detected type. The probes do not require
+// authentication.
+// It returns an error if the UniFi controller is unreachable or the type could not be detected.
+func (controller *Controller) DetectControllerType() (ControllerType, error) {
+	return controller.DetectControllerTypeWithContext(context.Background())
+}
+
+// DetectControllerTypeWithContext is the same as [Controller.DetectControllerType] but uses the
+// given context for the requests.
+func (controller *Controller) DetectControllerTypeWithContext(
+	ctx context.Context,
+) (ControllerType, error) {
+	// Redirects are not followed as they are used to tell the types of controller apart.
+	probeClient := *controller.httpClient
+	probeClient.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	// UniFi OS includes a CSRF token in the response of its root page, a classic UniFi Network
+	// application redirects to its management page instead.
+	res, err := probe(ctx, &probeClient, controller.baseUrl+"/")
+	if err != nil {
+		return "", fmt.Errorf("detecting controller type failed: %w", err)
+	}
+	if res.Header.Get("X-CSRF-Token") != "" {
+		controller.controllerType = ControllerTypeUnifiOs
+		return ControllerTypeUnifiOs, nil
+	}
+
+	// Both serve an unauthenticated status endpoint, but UniFi OS only behind its proxy path.
+	res, err = probe(ctx, &probeClient, controller.baseUrl+"/proxy/network/status")
+	if err != nil {
+		return "", fmt.Errorf("detecting controller type failed: %w", err)
+	}
+	if res.StatusCode == http.StatusOK {
+		controller.controllerType = ControllerTypeUnifiOs
+		return ControllerTypeUnifiOs, nil
+	}
+
+	res, err = probe(ctx, &probeClient, controller.baseUrl+"/status")
+	if err != nil {
+		return "", fmt.Errorf("detecting controller type failed: %w", err)
+	}
+	if res.StatusCode == http.StatusOK {
+		controller.controllerType = ControllerTypeClassic
+		return ControllerTypeClassic, nil
+	}
+
+	return "", errors.New("detecting controller type failed: no known endpoints found")
+}
+
+// Sends an unauthenticated GET request to the given endpointUrl using the given client and
+// discards the response body. It will return an error if the request fails.
+func probe(ctx context.Context, client *http.Client, endpointUrl string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpointUrl, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _ = io.Copy(io.Discard, res.Body)
+	return res, res.Body.Close()
+}
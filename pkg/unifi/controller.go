@@ -2,32 +2,52 @@ package unifi
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 )
 
 // A Controller is used to manage login state and to send requests linked to a UniFi controller.
 // A Controller can be created using the [ControllerBuilder] and its methods.
+// The session state of a Controller is safe for concurrent use by multiple goroutines, however
+// its configuration (e.g. [Controller.SetBaseUrl]) should not be changed while requests are in
+// progress.
 type Controller struct {
+	// The API key used to authenticate requests instead of a session (empty if not used).
+	apiKey string
 	// The URL at which the UniFi controller is reachable.
 	baseUrl string
-	// The type of Controller (some controllers use different endpoints e.g. UDM-Pro).
-	controllerType string
+	// The (normalized) type of Controller (some controllers use different endpoints e.g. UniFi OS).
+	controllerType ControllerType
 	// The cookie received from the UniFi controller after login.
 	cookie *http.Cookie
 	// The CSRF token received from the UniFi controller after login.
 	csrfToken string
 	// The http client used to make the requests.
 	httpClient *http.Client
-	// The transport used by the http client when making the request.
+	// The transport used by the http client when making the request (nil if a custom http client
+	// or round tripper is used).
 	httpTransport *http.Transport
-	// The user login info.
-	loginInfo loginInfo
+	// Indicates whether the certificate of the UniFi controller is only verified using the pinned
+	// fingerprint (no CA bundle), the CA verification of the transport must then remain disabled.
+	certificatePinnedOnly bool
+	// The provider of the user credentials used to (re-)authenticate.
+	credentialProvider CredentialProvider
+	// Guards the session state (apiKey, cookie, credentialProvider, csrfToken, sessionGeneration
+	// and relogin).
+	sessionMutex sync.RWMutex
+	// Incremented every time the session changes, used to detect already replaced sessions.
+	sessionGeneration uint64
+	// The re-authentication currently in progress, nil if there is none.
+	relogin *reloginCall
+	// The policy used to retry requests that failed due to a transient error.
+	retryPolicy RetryPolicy
 }
 
 // SetBaseUrl updates the URL at which the UniFi controller is reachable.
@@ -47,9 +67,43 @@ func (controller *Controller) SetBaseUrl(baseUrl string) error {
 	return nil
 }
 
-// SetControllerType updates the type of the UniFi controller.
-func (controller *Controller) SetControllerType(controllerType string) {
-	controller.controllerType = controllerType
+// SetApiKey updates the API key used to authenticate requests, an empty API key disables API key
+// authentication.
+func (controller *Controller) SetApiKey(apiKey string) {
+	controller.sessionMutex.Lock()
+	controller.apiKey = apiKey
+	controller.sessionMutex.Unlock()
+}
+
+// SetCredentialProvider updates the provider of the user credentials used by
+// [Controller.Authenticate] and to re-authenticate when the session expires. The credentials are
+// retrieved every time a login is required, so rotated credentials are used automatically.
+// Note that [Controller.Login] replaces and [Controller.Logout] clears the credential provider.
+func (controller *Controller) SetCredentialProvider(provider CredentialProvider) {
+	controller.sessionMutex.Lock()
+	controller.credentialProvider = provider
+	controller.sessionMutex.Unlock()
+}
+
+// SetControllerType updates the type of the UniFi controller, use
+// [Controller.DetectControllerType] to detect the type instead.
+// It returns an error if the type of controller is unknown.
+func (controller *Controller) SetControllerType(controllerType ControllerType) error {
+	err := controllerType.validate()
+	if err != nil {
+		return err
+	}
+	if controllerType == ControllerTypeAuto {
+		return errors.New("use DetectControllerType to detect the controller type")
+	}
+	controller.controllerType = controllerType.normalize()
+	return nil
+}
+
+// ControllerType returns the type of the UniFi controller, known UniFi OS controller models (e.g.
+// "UDM-Pro") are returned as [ControllerTypeUnifiOs].
+func (controller *Controller) ControllerType() ControllerType {
+	return controller.controllerType
 }
 
 // SetRequestTimout updates the timeout to use when making http requests.
@@ -64,10 +118,27 @@ func (controller *Controller) SetRequestTimout(timeout time.Duration) error {
 }
 
 // SetTlsVerification updates whether TLS verification will be used.
+// It has no effect if a custom http client or round tripper is used, or if a certificate
+// fingerprint is pinned without a CA bundle (see [ControllerBuilder.SetCertificateFingerprint]) as
+// the certificate is then always verified using the pinned fingerprint instead.
 func (controller *Controller) SetTlsVerification(verify bool) {
+	if controller.httpTransport == nil || controller.certificatePinnedOnly {
+		return
+	}
 	controller.httpTransport.TLSClientConfig.InsecureSkipVerify = !verify
 }
 
+// SetRetryPolicy updates the policy used to retry requests that failed due to a transient error.
+// It returns an error if the policy is invalid.
+func (controller *Controller) SetRetryPolicy(policy RetryPolicy) error {
+	err := policy.validate()
+	if err != nil {
+		return err
+	}
+	controller.retryPolicy = policy
+	return nil
+}
+
 // CreateDefaultSite creates and returns a reference to the default [Site] linked to this
 // [Controller].
 func (controller *Controller) CreateDefaultSite() *Site {
@@ -86,43 +157,138 @@ func (controller *Controller) CreateSite(name string) Site {
 	}
 }
 
+// Returns the endpoint of the UniFi Network application API for the given path based on the
+// [Controller] type.
+func (controller *Controller) createEndpointUrl(path string) string {
+	switch controller.controllerType {
+	case ControllerTypeUnifiOs:
+		return fmt.Sprintf("%s/proxy/network/api/%s", controller.baseUrl, path)
+	default:
+		return fmt.Sprintf("%s/api/%s", controller.baseUrl, path)
+	}
+}
+
+// Returns the endpoint of the v2 API of the UniFi Network application for the given path based on
+// the [Controller] type.
+func (controller *Controller) createV2EndpointUrl(path string) string {
+	switch controller.controllerType {
+	case ControllerTypeUnifiOs:
+		return fmt.Sprintf("%s/proxy/network/v2/api/%s", controller.baseUrl, path)
+	default:
+		return fmt.Sprintf("%s/v2/api/%s", controller.baseUrl, path)
+	}
+}
+
 // Executes a request with given method to the given endpointUrl, if a body is included it will be
 // transformed to JSON and added as a request body. If responseData is set the response body will
-// be parsed and the value will be stored in this variable.
-// It will return an error if the request fails for any reason.
+// be parsed and the value will be stored in this variable. The given context is attached to the
+// request and controls its cancellation and deadline.
+// Requests that fail due to a transient error are retried according to the [RetryPolicy] of the
+// [Controller], a request that is rejected because the session is no longer valid (e.g. after a
+// reboot of the UniFi controller) is replayed once after re-authenticating.
+// It will return an error if the request fails for any reason, a [ResponseError] is returned if the
+// UniFi controller responded with a non 2xx response code.
 func (controller *Controller) execute(
+	ctx context.Context,
 	method string,
 	endpointUrl string,
 	body any,
 	responseData any,
-) (res *http.Response, err error) {
-	var req *http.Request
-	if body == nil {
-		req, err = http.NewRequest(method, endpointUrl, http.NoBody)
-	} else {
-		requestBodyByteArray, marshalError := json.Marshal(body)
-		if marshalError != nil {
-			return nil, marshalError
+) (*http.Response, error) {
+	var requestBodyByteArray []byte
+	if body != nil {
+		var err error
+		requestBodyByteArray, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
 		}
+	}
+
+	reauthenticated := false
+	for attempt := 1; ; {
+		res, responseBodyByteArray, generation, err := controller.send(
+			ctx,
+			method,
+			endpointUrl,
+			requestBodyByteArray,
+		)
 
-		req, err = http.NewRequest(method, endpointUrl, bytes.NewBuffer(requestBodyByteArray))
+		// The session was rejected, re-authenticate and replay the request once.
+		if err == nil &&
+			!reauthenticated &&
+			!controller.usesApiKey() &&
+			isLoginRequired(res, responseBodyByteArray) {
+			reauthenticated = true
+			err = controller.reauthenticate(ctx, generation)
+			if err != nil {
+				return res, err
+			}
+			continue
+		}
+
+		if attempt < controller.retryPolicy.MaxAttempts &&
+			controller.retryPolicy.shouldRetry(ctx, method, res, err) {
+			err = controller.retryPolicy.wait(ctx, attempt)
+			if err != nil {
+				return res, err
+			}
+			attempt++
+			continue
+		}
+
+		if err != nil {
+			return res, err
+		}
+
+		return res, parseResponse(res, responseBodyByteArray, responseData)
+	}
+}
+
+// Returns whether requests are authenticated using an API key instead of a session.
+func (controller *Controller) usesApiKey() bool {
+	controller.sessionMutex.RLock()
+	defer controller.sessionMutex.RUnlock()
+
+	return controller.apiKey != ""
+}
+
+// Sends a single authorized request with given method and body to the given endpointUrl.
+// It returns the response, the read response body and the generation of the session used to
+// authorize the request. It will return an error if the request could not be sent or the
+// response body could not be read.
+func (controller *Controller) send(
+	ctx context.Context,
+	method string,
+	endpointUrl string,
+	requestBodyByteArray []byte,
+) (res *http.Response, responseBodyByteArray []byte, generation uint64, err error) {
+	var req *http.Request
+	if requestBodyByteArray == nil {
+		req, err = http.NewRequestWithContext(ctx, method, endpointUrl, http.NoBody)
+	} else {
+		req, err = http.NewRequestWithContext(
+			ctx,
+			method,
+			endpointUrl,
+			bytes.NewReader(requestBodyByteArray),
+		)
 	}
 	if err != nil {
-		return nil, err
+		return nil, nil, 0, err
 	}
 
-	err = controller.AuthorizeRequest(req)
+	generation, err = controller.authorizeRequest(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, generation, err
 	}
 
-	if body != nil && (method == http.MethodPost || method == http.MethodPut) {
+	if requestBodyByteArray != nil && (method == http.MethodPost || method == http.MethodPut) {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
 	res, err = controller.httpClient.Do(req)
 	if err != nil {
-		return res, err
+		return res, nil, generation, err
 	}
 	defer func(Body io.ReadCloser) {
 		closeError := Body.Close()
@@ -135,23 +301,48 @@ func (controller *Controller) execute(
 	// If response contains a CSRF token, replace the current one (in case it changes).
 	newCsrfToken := res.Header.Get(`X-CSRF-token`)
 	if newCsrfToken != "" {
-		controller.csrfToken = newCsrfToken
+		controller.sessionMutex.Lock()
+		// Ignore the token if the session was cleared in the meantime.
+		if controller.cookie != nil {
+			controller.csrfToken = newCsrfToken
+		}
+		controller.sessionMutex.Unlock()
 	}
 
-	// If no response data reference is included, the body is not parsed.
-	if responseData == nil {
-		return res, nil
+	responseBodyByteArray, err = io.ReadAll(res.Body)
+	return res, responseBodyByteArray, generation, err
+}
+
+// Returns whether the given response indicates the session used to authorize the request is no
+// longer valid, the UniFi controller responds with 401 or with 403 and `api.err.LoginRequired`.
+func isLoginRequired(res *http.Response, responseBodyByteArray []byte) bool {
+	switch res.StatusCode {
+	case http.StatusUnauthorized:
+		return true
+	case http.StatusForbidden:
+		return newResponseError(res.StatusCode, responseBodyByteArray).Meta.Msg ==
+			"api.err.LoginRequired"
+	default:
+		return false
 	}
+}
 
-	responseBodyByteArray, err := io.ReadAll(res.Body)
-	if err != nil {
-		return res, err
+// Parses the given response body into responseData (if set).
+// It returns a [ResponseError] if the response has a non 2xx response code or an error if the
+// response body could not be parsed.
+func parseResponse(res *http.Response, responseBodyByteArray []byte, responseData any) error {
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		// Still parse the response data (if possible) as it can contain extra information.
+		if responseData != nil {
+			_ = json.Unmarshal(responseBodyByteArray, responseData)
+		}
+		return newResponseError(res.StatusCode, responseBodyByteArray)
 	}
 
-	err = json.Unmarshal(responseBodyByteArray, responseData)
-	if err != nil {
-		return res, err
+	// If no response data reference is included, the body is not parsed.
+	if responseData == nil {
+		return nil
 	}
 
-	return res, nil
+	return json.Unmarshal(responseBodyByteArray, responseData)
 }
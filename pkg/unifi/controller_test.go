@@ -0,0 +1,104 @@
+package unifi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// Builds a [Controller] for the given [testServer] using static credentials, the session of the
+// controller is valid until the UniFi controller rejects it.
+func newAuthenticatedTestController(t *testing.T, server *testServer) *Controller {
+	t.Helper()
+
+	controller := newExpiredTestController(t, server)
+	controller.cookie = &http.Cookie{Name: "TOKEN", Value: "rejected-token"}
+	controller.csrfToken = "rejected-csrf-token"
+
+	return controller
+}
+
+// Responds with the given status code and body to the first request and with an empty data array
+// afterwards.
+func rejectFirstHandler(server *testServer, statusCode int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if server.requests.Load() == 1 {
+			w.WriteHeader(statusCode)
+			_, _ = w.Write([]byte(body))
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}
+}
+
+func TestExecuteReplaysRejectedSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+	}{
+		{name: "unauthorized", statusCode: 401, body: ``},
+		{
+			name:       "login required",
+			statusCode: 403,
+			body:       `{"meta":{"rc":"error","msg":"api.err.LoginRequired"},"data":[]}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+			server.handler = rejectFirstHandler(server, test.statusCode, test.body)
+			site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+			_, err := site.GetAllFirewallGroups()
+			if err != nil {
+				t.Fatalf("expected request to succeed after re-authenticating, got %s", err)
+			}
+			if logins := server.logins.Load(); logins != 1 {
+				t.Errorf("expected 1 login, got %d", logins)
+			}
+			if requests := server.requests.Load(); requests != 2 {
+				t.Errorf("expected 2 requests, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestExecuteReplaysRejectedSessionOnce(t *testing.T) {
+	server := newTestServer(t)
+	server.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	_, err := site.GetAllFirewallGroups()
+	var responseError *ResponseError
+	if !errors.As(err, &responseError) || responseError.StatusCode != 401 {
+		t.Fatalf("expected response error with status code 401, got %v", err)
+	}
+	if logins := server.logins.Load(); logins != 1 {
+		t.Errorf("expected 1 login, got %d", logins)
+	}
+	if requests := server.requests.Load(); requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestExecuteDoesNotReplayForbidden(t *testing.T) {
+	server := newTestServer(t)
+	server.handler = rejectFirstHandler(
+		server,
+		http.StatusForbidden,
+		`{"meta":{"rc":"error","msg":"api.err.NoPermission"},"data":[]}`,
+	)
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	_, err := site.GetAllFirewallGroups()
+	var responseError *ResponseError
+	if !errors.As(err, &responseError) || responseError.StatusCode != 403 {
+		t.Fatalf("expected response error with status code 403, got %v", err)
+	}
+	if logins := server.logins.Load(); logins != 0 {
+		t.Errorf("expected no login, got %d", logins)
+	}
+}
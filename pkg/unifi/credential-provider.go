@@ -0,0 +1,142 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Credentials are the user credentials used to login at the UniFi controller.
+// The JSON representation is used by the [FileCredentialProvider] and [CommandCredentialProvider].
+type Credentials struct {
+	// The username of a (local) UniFi user.
+	Username string `json:"username"`
+	// The password of the user.
+	Password string `json:"password"`
+	// The TOTP secret (base32) used to generate two-factor authentication codes (optional).
+	TotpSecret string `json:"totp_secret,omitempty"`
+}
+
+// A CredentialProvider provides the user credentials to the [Controller], it is queried every
+// time the [Controller] needs to (re-)authenticate so credentials can be rotated without
+// rebuilding the [Controller]. See [Controller.SetCredentialProvider].
+type CredentialProvider interface {
+	// Credentials returns the current user credentials.
+	// It returns an error if the credentials could not be retrieved.
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// StaticCredentialProvider is a [CredentialProvider] that always provides the same credentials.
+type StaticCredentialProvider Credentials
+
+// Credentials returns the static credentials.
+func (provider *StaticCredentialProvider) Credentials(context.Context) (Credentials, error) {
+	return Credentials(*provider), nil
+}
+
+// EnvironmentCredentialProvider is a [CredentialProvider] that reads the credentials from the
+// environment variables with the given names every time they are required.
+type EnvironmentCredentialProvider struct {
+	// The name of the environment variable containing the username.
+	UsernameVariable string
+	// The name of the environment variable containing the password.
+	PasswordVariable string
+	// The name of the environment variable containing the TOTP secret (optional).
+	TotpSecretVariable string
+}
+
+// Credentials returns the credentials read from the environment variables.
+// It returns an error if the username or password environment variable is not set.
+func (provider *EnvironmentCredentialProvider) Credentials(context.Context) (Credentials, error) {
+	username, ok := os.LookupEnv(provider.UsernameVariable)
+	if !ok {
+		return Credentials{}, fmt.Errorf(
+			"environment variable %q is not set",
+			provider.UsernameVariable,
+		)
+	}
+
+	password, ok := os.LookupEnv(provider.PasswordVariable)
+	if !ok {
+		return Credentials{}, fmt.Errorf(
+			"environment variable %q is not set",
+			provider.PasswordVariable,
+		)
+	}
+
+	credentials := Credentials{Username: username, Password: password}
+	if provider.TotpSecretVariable != "" {
+		credentials.TotpSecret = os.Getenv(provider.TotpSecretVariable)
+	}
+	return credentials, nil
+}
+
+// FileCredentialProvider is a [CredentialProvider] that reads the credentials from a JSON file
+// (see [Credentials] for the format). Instead of watching the file, it is read and parsed every
+// time the credentials are required (only at (re-)login), so changes to the file are picked up by
+// the next login without keeping the credentials in memory in between.
+type FileCredentialProvider struct {
+	// The path of the credentials file.
+	Path string
+}
+
+// Credentials returns the credentials read from the credentials file.
+// It returns an error if the file could not be read or parsed.
+func (provider *FileCredentialProvider) Credentials(context.Context) (Credentials, error) {
+	byteArray, err := os.ReadFile(provider.Path)
+	if err != nil {
+		return Credentials{}, err
+	}
+
+	return parseCredentials(byteArray)
+}
+
+// CommandCredentialProvider is a [CredentialProvider] that executes a command (e.g. the CLI of a
+// secret manager) every time the credentials are required. The command should write the
+// credentials as JSON (see [Credentials] for the format) to its standard output.
+type CommandCredentialProvider struct {
+	// The name or path of the command.
+	Name string
+	// The arguments passed to the command.
+	Args []string
+}
+
+// Credentials returns the credentials written to the standard output by the command.
+// It returns an error if the command fails or its output could not be parsed.
+func (provider *CommandCredentialProvider) Credentials(ctx context.Context) (Credentials, error) {
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, provider.Name, provider.Args...)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+
+	err := command.Run()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			return Credentials{}, fmt.Errorf("credential command failed: %w", err)
+		}
+		return Credentials{}, fmt.Errorf("credential command failed: %w: %s", err, message)
+	}
+
+	return parseCredentials(stdout.Bytes())
+}
+
+// Parses the given JSON representation of [Credentials].
+// It returns an error if the JSON is invalid or the username or password is missing.
+func parseCredentials(byteArray []byte) (Credentials, error) {
+	credentials := Credentials{}
+	err := json.Unmarshal(byteArray, &credentials)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("failed to parse credentials: %w", err)
+	}
+
+	if credentials.Username == "" || credentials.Password == "" {
+		return Credentials{}, errors.New("credentials must contain a username and password")
+	}
+	return credentials, nil
+}
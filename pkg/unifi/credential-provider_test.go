@@ -0,0 +1,58 @@
+package unifi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Writes the given content to the file at the given path.
+func writeCredentialsFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(content), 0600)
+	if err != nil {
+		t.Fatalf("writing credentials file failed: %s", err)
+	}
+}
+
+func TestFileCredentialProviderReadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	writeCredentialsFile(t, path, `{"username":"user","password":"old"}`)
+	provider := &FileCredentialProvider{Path: path}
+
+	credentials, err := provider.Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("retrieving credentials failed: %s", err)
+	}
+	if credentials.Password != "old" {
+		t.Errorf("expected password old, got %s", credentials.Password)
+	}
+
+	// Same size, the file is read again regardless of its size and modification time.
+	writeCredentialsFile(t, path, `{"username":"user","password":"new"}`)
+	credentials, err = provider.Credentials(context.Background())
+	if err != nil {
+		t.Fatalf("retrieving credentials failed: %s", err)
+	}
+	if credentials.Password != "new" {
+		t.Errorf("expected changed password new, got %s", credentials.Password)
+	}
+}
+
+func TestFileCredentialProviderInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	provider := &FileCredentialProvider{Path: path}
+
+	_, err := provider.Credentials(context.Background())
+	if err == nil {
+		t.Error("expected an error for a missing file")
+	}
+
+	writeCredentialsFile(t, path, `{"username":"user"}`)
+	_, err = provider.Credentials(context.Background())
+	if err == nil {
+		t.Error("expected an error for credentials without password")
+	}
+}
@@ -0,0 +1,144 @@
+package unifi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestApplyDevicePortOverridePreservesOtherOverrides(t *testing.T) {
+	tests := []struct {
+		name         string
+		portOverride PortOverride
+		expected     string
+	}{
+		{
+			name:         "replace",
+			portOverride: PortOverride{PortIdx: 2, Name: "Camera"},
+			expected: `[{"port_idx":1,"name":"Uplink","unknown_setting":{"kept":true}},` +
+				`{"port_idx":2,"name":"Camera"}]`,
+		},
+		{
+			name:         "add",
+			portOverride: PortOverride{PortIdx: 3, PortSettings: PortSettings{PoeMode: "off"}},
+			expected: `[{"port_idx":1,"name":"Uplink","unknown_setting":{"kept":true}},` +
+				`{"port_idx":2,"name":"Printer","fec_mode":"rs-fec"},` +
+				`{"port_idx":3,"poe_mode":"off"}]`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+			var body struct {
+				PortOverrides json.RawMessage `json:"port_overrides"`
+			}
+			server.handler = func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/s/default/rest/device/device-id" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[{"_id":"device-id",` +
+						`"port_overrides":[` +
+						`{"port_idx":1,"name":"Uplink","unknown_setting":{"kept":true}},` +
+						`{"port_idx":2,"name":"Printer","fec_mode":"rs-fec"}]}]}`))
+				case http.MethodPut:
+					byteArray, _ := io.ReadAll(r.Body)
+					if err := json.Unmarshal(byteArray, &body); err != nil {
+						t.Errorf("parsing request body %q failed: %s", byteArray, err)
+					}
+					_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+				}
+			}
+			site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+			_, err := site.ApplyDevicePortOverride("device-id", test.portOverride)
+			if err != nil {
+				t.Fatalf("applying port override failed: %s", err)
+			}
+			if string(body.PortOverrides) != test.expected {
+				t.Errorf("expected port overrides %s, got %s", test.expected, body.PortOverrides)
+			}
+		})
+	}
+}
+
+func TestApplyDevicePortOverrideUnknownDevice(t *testing.T) {
+	server := newTestServer(t)
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	_, err := site.ApplyDevicePortOverride("unknown", PortOverride{PortIdx: 1})
+	if !errors.Is(err, NotFoundError) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if requests := server.requests.Load(); requests != 1 {
+		t.Errorf("expected only the device to be fetched, got %d requests", requests)
+	}
+}
+
+func TestDeviceCommands(t *testing.T) {
+	tests := []struct {
+		name    string
+		execute func(site *Site) (DeviceResponse, error)
+		path    string
+		body    string
+	}{
+		{
+			name: "locate",
+			execute: func(site *Site) (DeviceResponse, error) {
+				return site.SetDeviceLocate("aa:bb:cc:dd:ee:ff", true)
+			},
+			path: "/api/s/default/cmd/devmgr",
+			body: `{"cmd":"set-locate","mac":"aa:bb:cc:dd:ee:ff"}`,
+		},
+		{
+			name: "unlocate",
+			execute: func(site *Site) (DeviceResponse, error) {
+				return site.SetDeviceLocate("aa:bb:cc:dd:ee:ff", false)
+			},
+			path: "/api/s/default/cmd/devmgr",
+			body: `{"cmd":"unset-locate","mac":"aa:bb:cc:dd:ee:ff"}`,
+		},
+		{
+			name: "upgrade from URL",
+			execute: func(site *Site) (DeviceResponse, error) {
+				return site.UpgradeDeviceFromUrl("aa:bb:cc:dd:ee:ff", "https://firmware/fw.bin")
+			},
+			path: "/api/s/default/cmd/devmgr",
+			body: `{"cmd":"upgrade-external","mac":"aa:bb:cc:dd:ee:ff",` +
+				`"url":"https://firmware/fw.bin"}`,
+		},
+		{
+			name: "forget",
+			execute: func(site *Site) (DeviceResponse, error) {
+				return site.ForgetDevice("aa:bb:cc:dd:ee:ff")
+			},
+			path: "/api/s/default/cmd/sitemgr",
+			body: `{"cmd":"delete-device","mac":"aa:bb:cc:dd:ee:ff"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+			var body []byte
+			server.handler = func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != test.path {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				body, _ = io.ReadAll(r.Body)
+				_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+			}
+			site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+			_, err := test.execute(site)
+			if err != nil {
+				t.Fatalf("executing command failed: %s", err)
+			}
+			if string(body) != test.body {
+				t.Errorf("expected body %s, got %s", test.body, body)
+			}
+		})
+	}
+}
@@ -12,7 +12,7 @@ func Example() {
 	// Configure the builder and build the controller.
 	controller, err := controllerBuilder.
 		SetBaseUrl("https://unifi").
-		SetControllerType("UDM-Pro").
+		SetControllerType(unifi.ControllerTypeUnifiOs).
 		SetRequestTimout(30 * time.Second).
 		SetTlsVerification(false).
 		Build()
@@ -1,10 +1,6 @@
 package unifi
 
-import (
-	"errors"
-	"fmt"
-	"net/http"
-)
+import "context"
 
 // FirewallGroupResponse is the representation of a response of a firewall group request.
 type FirewallGroupResponse struct {
@@ -20,6 +16,13 @@ type FirewallGroupResponseData struct {
 	*DataValidationError
 }
 
+// The `rest/firewallgroup` endpoint managing the firewall groups of a [Site].
+var firewallGroupResource = restResource[FirewallGroup, FirewallGroupResponse]{
+	path:       "rest/firewallgroup",
+	name:       "firewall group",
+	pluralName: "firewall groups",
+}
+
 // FirewallGroup is the representation of a firewall group.
 type FirewallGroup struct {
 	// The date of the group based on the GroupType:
@@ -43,41 +46,30 @@ type FirewallGroup struct {
 // CreateFirewallGroup creates a new firewall group linked to this [Site] using the given
 // firewall group data. It will return an error if the creation of the firewall group failed.
 func (site *Site) CreateFirewallGroup(firewallGroup FirewallGroup) (FirewallGroupResponse, error) {
-	responseData := FirewallGroupResponse{}
-	endpointUrl := site.createEndpointUrl("rest/firewallgroup", "")
-
-	res, err := site.controller.execute(http.MethodPost, endpointUrl, firewallGroup, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("creating firewall group failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.CreateFirewallGroupWithContext(context.Background(), firewallGroup)
+}
 
-	return responseData, nil
+// CreateFirewallGroupWithContext is the same as [Site.CreateFirewallGroup] but uses the given
+// context for the request.
+func (site *Site) CreateFirewallGroupWithContext(
+	ctx context.Context,
+	firewallGroup FirewallGroup,
+) (FirewallGroupResponse, error) {
+	return firewallGroupResource.create(ctx, site, firewallGroup)
 }
 
 // GetAllFirewallGroups returns all firewall groups linked to this [Site].
 // It will return an error if it fails to fetch the firewall groups.
 func (site *Site) GetAllFirewallGroups() (FirewallGroupResponse, error) {
-	endpointUrl := site.createEndpointUrl("rest/firewallgroup", "")
-	responseData := FirewallGroupResponse{}
-
-	res, err := site.controller.execute(http.MethodGet, endpointUrl, nil, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("retreiving firewall groups failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.GetAllFirewallGroupsWithContext(context.Background())
+}
 
-	return responseData, nil
+// GetAllFirewallGroupsWithContext is the same as [Site.GetAllFirewallGroups] but uses the given
+// context for the request.
+func (site *Site) GetAllFirewallGroupsWithContext(
+	ctx context.Context,
+) (FirewallGroupResponse, error) {
+	return firewallGroupResource.getAll(ctx, site)
 }
 
 // GetFirewallGroup returns the firewall group linked to the given ID and this [Site].
@@ -85,21 +77,16 @@ func (site *Site) GetAllFirewallGroups() (FirewallGroupResponse, error) {
 // with the given ID is present or the ID is invalid no error but a response with an empty data
 // array will be returned.
 func (site *Site) GetFirewallGroup(id string) (FirewallGroupResponse, error) {
-	endpointUrl := site.createEndpointUrl("rest/firewallgroup", id)
-	responseData := FirewallGroupResponse{}
-
-	res, err := site.controller.execute(http.MethodGet, endpointUrl, nil, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("retreiving firewall group failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.GetFirewallGroupWithContext(context.Background(), id)
+}
 
-	return responseData, nil
+// GetFirewallGroupWithContext is the same as [Site.GetFirewallGroup] but uses the given context for
+// the request.
+func (site *Site) GetFirewallGroupWithContext(
+	ctx context.Context,
+	id string,
+) (FirewallGroupResponse, error) {
+	return firewallGroupResource.get(ctx, site, id)
 }
 
 // UpdateFirewallGroup updates the firewall group linked to the given ID and this [Site] using the
@@ -108,39 +95,30 @@ func (site *Site) UpdateFirewallGroup(
 	id string,
 	firewallGroup FirewallGroup,
 ) (FirewallGroupResponse, error) {
-	endpointUrl := site.createEndpointUrl("rest/firewallgroup", id)
-	responseData := FirewallGroupResponse{}
-
-	res, err := site.controller.execute(http.MethodPut, endpointUrl, firewallGroup, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("firewall group update failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.UpdateFirewallGroupWithContext(context.Background(), id, firewallGroup)
+}
 
-	return responseData, nil
+// UpdateFirewallGroupWithContext is the same as [Site.UpdateFirewallGroup] but uses the given
+// context for the request.
+func (site *Site) UpdateFirewallGroupWithContext(
+	ctx context.Context,
+	id string,
+	firewallGroup FirewallGroup,
+) (FirewallGroupResponse, error) {
+	return firewallGroupResource.update(ctx, site, id, firewallGroup)
 }
 
 // DeleteFirewallGroup deletes the firewall group linked to the given ID and this [Site].
 // It will return an error if the deletion of the firewall group failed.
 func (site *Site) DeleteFirewallGroup(id string) (FirewallGroupResponse, error) {
-	endpointUrl := site.createEndpointUrl("rest/firewallgroup", id)
-	responseData := FirewallGroupResponse{}
-
-	res, err := site.controller.execute(http.MethodDelete, endpointUrl, nil, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("deleting firewall group failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.DeleteFirewallGroupWithContext(context.Background(), id)
+}
 
-	return responseData, nil
+// DeleteFirewallGroupWithContext is the same as [Site.DeleteFirewallGroup] but uses the given
+// context for the request.
+func (site *Site) DeleteFirewallGroupWithContext(
+	ctx context.Context,
+	id string,
+) (FirewallGroupResponse, error) {
+	return firewallGroupResource.delete(ctx, site, id)
 }
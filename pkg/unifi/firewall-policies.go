@@ -0,0 +1,254 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// The `firewall-policies` endpoint (v2 API) managing the firewall policies of a [Site].
+var firewallPolicyResource = v2Resource[FirewallPolicy]{
+	path:       "firewall-policies",
+	name:       "firewall policy",
+	pluralName: "firewall policies",
+}
+
+// FirewallPolicy is the representation of a firewall policy, a policy matches the traffic from
+// its source zone to its destination zone (see [FirewallZone]).
+// Firewall policies require UniFi Network application 9.0 or newer, see
+// [CapabilityZoneBasedFirewall].
+type FirewallPolicy struct {
+	// The policy ID.
+	Id string `json:"_id,omitempty"`
+	// The policy name.
+	Name string `json:"name,omitempty"`
+	// The description of the policy.
+	Description string `json:"description,omitempty"`
+	// Indicates whether the policy is active.
+	Enabled bool `json:"enabled"`
+	// Indicates whether the policy is a predefined policy, predefined policies can not be changed.
+	Predefined bool `json:"predefined,omitempty"`
+	// The policy index, policies with a lower index are processed (matched) first. Custom policies
+	// start at index 10000, see [Site.OrderFirewallPolicies].
+	Index int `json:"index,omitempty"`
+	// What action the policy should take, options:
+	//	- ALLOW: The traffic is allowed.
+	//	- BLOCK: The traffic is dropped and no response is sent back.
+	//	- REJECT: The traffic is dropped and a response is sent back to the source.
+	Action string `json:"action,omitempty"`
+	// Indicates whether the return traffic is allowed automatically (when Action is `ALLOW`).
+	CreateAllowRespond bool `json:"create_allow_respond"`
+	// The source of the matched traffic.
+	Source FirewallPolicyTarget `json:"source"`
+	// The destination of the matched traffic.
+	Destination FirewallPolicyTarget `json:"destination"`
+	// The IP version of the matched traffic, options: BOTH, IPV4, IPV6.
+	IpVersion string `json:"ip_version,omitempty"`
+	// The matched protocol e.g. all, tcp_udp, tcp, udp, icmp (see [FirewallRule.Protocol]).
+	Protocol string `json:"protocol,omitempty"`
+	// Inverts the chosen Protocol, matches all protocols except the chosen one.
+	MatchOppositeProtocol bool `json:"match_opposite_protocol"`
+	// The IPv4 ICMP type when Protocol `icmp` is used e.g. ANY (see [FirewallRule.ICMPTypename]).
+	IcmpTypename string `json:"icmp_typename,omitempty"`
+	// The IPv6 ICMP type when Protocol `icmpv6` is used e.g. ANY (see
+	// [FirewallRule.ICMPv6Typename]).
+	IcmpV6Typename string `json:"icmp_v6_typename,omitempty"`
+	// The connection states that are matched, options:
+	//	- ALL: All connection states.
+	//	- RESPOND_ONLY: Only established and related connections.
+	//	- CUSTOM: The connection states in ConnectionStates.
+	ConnectionStateType string `json:"connection_state_type,omitempty"`
+	// The matched connection states when ConnectionStateType is `CUSTOM`, options: NEW,
+	// ESTABLISHED, RELATED, INVALID.
+	ConnectionStates []string `json:"connection_states,omitempty"`
+	// Indicates whether only IPsec (encrypted) traffic is matched.
+	MatchIpSec bool `json:"match_ip_sec"`
+	// Generates a syslog entry when this policy is matched.
+	Logging bool `json:"logging"`
+	// The schedule during which the policy is active.
+	Schedule *TrafficSchedule `json:"schedule,omitempty"`
+}
+
+// FirewallPolicyTarget is the representation of the source or destination of a [FirewallPolicy].
+type FirewallPolicyTarget struct {
+	// The ID of the zone (see [FirewallZone]).
+	ZoneId string `json:"zone_id,omitempty"`
+	// Determines which traffic of the zone is matched, options:
+	//	- ANY: All traffic of the zone.
+	//	- IP: The IP addresses in Ips (or the IP group IpGroupId).
+	//	- NETWORK: The networks in NetworkIds.
+	//	- CLIENT: The clients in ClientMacs.
+	//	- MAC: The MAC addresses in MacAddresses.
+	//	- REGION: The regions in Regions (destination only).
+	//	- WEB: The domains in WebDomains (destination only).
+	//	- APP: The applications in AppIds (destination only).
+	//	- APP_CATEGORY: The application categories in AppCategoryIds (destination only).
+	MatchingTarget string `json:"matching_target,omitempty"`
+	// Indicates whether the IP addresses are defined in the policy or using an IP group, options:
+	//	- SPECIFIC: The IP addresses in Ips.
+	//	- OBJECT: The IP group IpGroupId.
+	MatchingTargetType string `json:"matching_target_type,omitempty"`
+	// The matched IP addresses, subnets and/or ranges.
+	Ips []string `json:"ips,omitempty"`
+	// The ID of the matched IP group (see [FirewallGroup]).
+	IpGroupId string `json:"ip_group_id,omitempty"`
+	// Inverts the matched IP addresses, matches all IP addresses except the chosen ones.
+	MatchOppositeIps bool `json:"match_opposite_ips"`
+	// The IDs of the matched networks (see [Network]).
+	NetworkIds []string `json:"network_ids,omitempty"`
+	// The MAC addresses of the matched clients.
+	ClientMacs []string `json:"client_macs,omitempty"`
+	// The matched MAC addresses.
+	MacAddresses []string `json:"mac_addresses,omitempty"`
+	// The matched regions as ISO 3166-1 alpha-2 country codes e.g. "BE".
+	Regions []string `json:"regions,omitempty"`
+	// The matched domains e.g. "example.com".
+	WebDomains []string `json:"web_domains,omitempty"`
+	// The IDs of the matched applications.
+	AppIds []int `json:"app_ids,omitempty"`
+	// The IDs of the matched application categories.
+	AppCategoryIds []int `json:"app_category_ids,omitempty"`
+	// Determines which ports are matched, options:
+	//	- ANY: All ports.
+	//	- SPECIFIC: The port(s) and/or port range(s) in Port.
+	//	- OBJECT: The port group PortGroupId.
+	PortMatchingType string `json:"port_matching_type,omitempty"`
+	// Comma separated port(s) and/or port range(s) e.g. "80,443,8000-9000".
+	Port string `json:"port,omitempty"`
+	// The ID of the matched port group (see [FirewallGroup]).
+	PortGroupId string `json:"port_group_id,omitempty"`
+	// Inverts the matched ports, matches all ports except the chosen ones.
+	MatchOppositePorts bool `json:"match_opposite_ports"`
+}
+
+// CreateFirewallPolicy creates a new firewall policy linked to this [Site] using the given
+// firewall policy data and returns the created firewall policy.
+// It will return an error if the creation of the firewall policy failed.
+func (site *Site) CreateFirewallPolicy(firewallPolicy FirewallPolicy) (FirewallPolicy, error) {
+	return site.CreateFirewallPolicyWithContext(context.Background(), firewallPolicy)
+}
+
+// CreateFirewallPolicyWithContext is the same as [Site.CreateFirewallPolicy] but uses the given
+// context for the request.
+func (site *Site) CreateFirewallPolicyWithContext(
+	ctx context.Context,
+	firewallPolicy FirewallPolicy,
+) (FirewallPolicy, error) {
+	return firewallPolicyResource.create(ctx, site, firewallPolicy)
+}
+
+// GetAllFirewallPolicies returns all firewall policies (including the predefined policies) linked
+// to this [Site]. It will return an error if it fails to fetch the firewall policies.
+func (site *Site) GetAllFirewallPolicies() ([]FirewallPolicy, error) {
+	return site.GetAllFirewallPoliciesWithContext(context.Background())
+}
+
+// GetAllFirewallPoliciesWithContext is the same as [Site.GetAllFirewallPolicies] but uses the
+// given context for the request.
+func (site *Site) GetAllFirewallPoliciesWithContext(
+	ctx context.Context,
+) ([]FirewallPolicy, error) {
+	return firewallPolicyResource.getAll(ctx, site)
+}
+
+// UpdateFirewallPolicy updates the firewall policy linked to the given ID and this [Site] using
+// the given firewall policy data and returns the updated firewall policy. The given data is merged
+// over the current firewall policy, so its fields not included in [FirewallPolicy] are preserved.
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no firewall policy has
+// the given ID or an error if the update of the firewall policy failed.
+func (site *Site) UpdateFirewallPolicy(
+	id string,
+	firewallPolicy FirewallPolicy,
+) (FirewallPolicy, error) {
+	return site.UpdateFirewallPolicyWithContext(context.Background(), id, firewallPolicy)
+}
+
+// UpdateFirewallPolicyWithContext is the same as [Site.UpdateFirewallPolicy] but uses the given
+// context for the request.
+func (site *Site) UpdateFirewallPolicyWithContext(
+	ctx context.Context,
+	id string,
+	firewallPolicy FirewallPolicy,
+) (FirewallPolicy, error) {
+	return firewallPolicyResource.update(ctx, site, id, firewallPolicy)
+}
+
+// OrderFirewallPolicies changes the order of the firewall policies linked to the given IDs and
+// this [Site] to the order of the given IDs, the policies take over the indexes currently used by
+// them (lowest first). Only the index of a policy is changed, its other settings (including the
+// ones not included in [FirewallPolicy]) are preserved. Only policies with a changed index are
+// updated, one request per policy.
+// It will return an error if an ID is included more than once (before any request is sent), an
+// error matching [NotFoundError] (using [errors.Is]) if no firewall policy has one of the given IDs
+// or an error if the update of a firewall policy failed. The updates are not
+// applied atomically: if an update fails, the policies updated before the failure keep their new
+// index, so the order can be partially applied and an index can be used by two policies until
+// the order is applied again.
+func (site *Site) OrderFirewallPolicies(ids []string) error {
+	return site.OrderFirewallPoliciesWithContext(context.Background(), ids)
+}
+
+// OrderFirewallPoliciesWithContext is the same as [Site.OrderFirewallPolicies] but uses the given
+// context for the requests.
+func (site *Site) OrderFirewallPoliciesWithContext(ctx context.Context, ids []string) error {
+	for i, id := range ids {
+		if slices.Contains(ids[:i], id) {
+			return fmt.Errorf("firewall policy ID %q is included more than once", id)
+		}
+	}
+
+	firewallPolicies, err := firewallPolicyResource.getAllRaw(ctx, site)
+	if err != nil {
+		return err
+	}
+
+	orderedPolicies := make([]rawObject[FirewallPolicy], 0, len(ids))
+	indexes := make([]int, 0, len(ids))
+	for _, id := range ids {
+		index := slices.IndexFunc(
+			firewallPolicies,
+			func(firewallPolicy rawObject[FirewallPolicy]) bool {
+				return firewallPolicy.object.Id == id
+			},
+		)
+		if index < 0 {
+			return fmt.Errorf("%w: no firewall policy with ID %q", NotFoundError, id)
+		}
+		orderedPolicies = append(orderedPolicies, firewallPolicies[index])
+		indexes = append(indexes, firewallPolicies[index].object.Index)
+	}
+	slices.Sort(indexes)
+
+	for i, firewallPolicy := range orderedPolicies {
+		if firewallPolicy.object.Index == indexes[i] {
+			continue
+		}
+
+		err = firewallPolicy.set("index", indexes[i])
+		if err != nil {
+			return err
+		}
+		_, err = firewallPolicyResource.updateRaw(
+			ctx,
+			site,
+			firewallPolicy.object.Id,
+			firewallPolicy.fields,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteFirewallPolicy deletes the firewall policy linked to the given ID and this [Site].
+// It will return an error if the deletion of the firewall policy failed.
+func (site *Site) DeleteFirewallPolicy(id string) error {
+	return site.DeleteFirewallPolicyWithContext(context.Background(), id)
+}
+
+// DeleteFirewallPolicyWithContext is the same as [Site.DeleteFirewallPolicy] but uses the given
+// context for the request.
+func (site *Site) DeleteFirewallPolicyWithContext(ctx context.Context, id string) error {
+	return firewallPolicyResource.delete(ctx, site, id)
+}
@@ -0,0 +1,134 @@
+package unifi
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// v2TestStore is an in-memory v2 API endpoint storing its objects as raw JSON fields.
+type v2TestStore struct {
+	mutex sync.Mutex
+	// The objects in the order they are returned.
+	objects []map[string]any
+	// The IDs of the updated objects in the order they were updated.
+	updates []string
+}
+
+// Handles a GET (all objects) or PUT (single object) request to the endpoint with the given path.
+func (store *v2TestStore) handler(t *testing.T, path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		store.mutex.Lock()
+		defer store.mutex.Unlock()
+
+		endpoint := "/v2/api/site/default/" + path
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == endpoint:
+			_ = json.NewEncoder(w).Encode(store.objects)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, endpoint+"/"):
+			id := strings.TrimPrefix(r.URL.Path, endpoint+"/")
+			byteArray, _ := io.ReadAll(r.Body)
+			object := map[string]any{}
+			if err := json.Unmarshal(byteArray, &object); err != nil {
+				t.Errorf("parsing request body %q failed: %s", byteArray, err)
+			}
+			for i := range store.objects {
+				if store.objects[i]["_id"] == id {
+					store.objects[i] = object
+				}
+			}
+			store.updates = append(store.updates, id)
+			_, _ = w.Write(byteArray)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+// Returns the object with the given ID.
+func (store *v2TestStore) object(id string) map[string]any {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	for _, object := range store.objects {
+		if object["_id"] == id {
+			return object
+		}
+	}
+	return nil
+}
+
+func TestOrderFirewallPoliciesPreservesUnknownFields(t *testing.T) {
+	store := &v2TestStore{objects: []map[string]any{
+		{"_id": "a", "index": 10000.0, "name": "A", "schedule": map[string]any{"mode": "ALWAYS"}},
+		{"_id": "b", "index": 10001.0, "name": "B", "logging": true},
+		{"_id": "c", "index": 10002.0, "name": "C", "unknown_field": "kept"},
+		{"_id": "d", "index": 20000.0, "name": "D"},
+	}}
+	server := newTestServer(t)
+	server.handler = store.handler(t, "firewall-policies")
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	err := site.OrderFirewallPolicies([]string{"c", "a", "b"})
+	if err != nil {
+		t.Fatalf("ordering firewall policies failed: %s", err)
+	}
+
+	expectedIndexes := map[string]float64{"a": 10001, "b": 10002, "c": 10000, "d": 20000}
+	for id, expectedIndex := range expectedIndexes {
+		if index := store.object(id)["index"]; index != expectedIndex {
+			t.Errorf("expected index %v for policy %s, got %v", expectedIndex, id, index)
+		}
+	}
+	if value := store.object("c")["unknown_field"]; value != "kept" {
+		t.Errorf("expected unknown field to be preserved, got %v", value)
+	}
+	if value := store.object("b")["logging"]; value != true {
+		t.Errorf("expected logging to be preserved, got %v", value)
+	}
+	if schedule, ok := store.object("a")["schedule"].(map[string]any); !ok ||
+		schedule["mode"] != "ALWAYS" {
+		t.Errorf("expected schedule to be preserved, got %v", store.object("a")["schedule"])
+	}
+	if len(store.updates) != 3 {
+		t.Errorf("expected 3 updates, got %v", store.updates)
+	}
+}
+
+func TestOrderFirewallPoliciesUnknownId(t *testing.T) {
+	store := &v2TestStore{objects: []map[string]any{{"_id": "a", "index": 10000.0}}}
+	server := newTestServer(t)
+	server.handler = store.handler(t, "firewall-policies")
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	err := site.OrderFirewallPolicies([]string{"a", "unknown"})
+	if !errors.Is(err, NotFoundError) {
+		t.Errorf("expected a not found error for the unknown ID, got %v", err)
+	}
+	if len(store.updates) != 0 {
+		t.Errorf("expected no updates, got %v", store.updates)
+	}
+}
+
+func TestOrderFirewallPoliciesDuplicateId(t *testing.T) {
+	store := &v2TestStore{objects: []map[string]any{
+		{"_id": "a", "index": 10000.0},
+		{"_id": "b", "index": 10001.0},
+	}}
+	server := newTestServer(t)
+	server.handler = store.handler(t, "firewall-policies")
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	err := site.OrderFirewallPolicies([]string{"a", "b", "a"})
+	if err == nil {
+		t.Fatal("expected an error for the duplicate ID")
+	}
+	if requests := server.requests.Load(); requests != 0 {
+		t.Errorf("expected no requests, got %d", requests)
+	}
+}
@@ -1,10 +1,6 @@
 package unifi
 
-import (
-	"errors"
-	"fmt"
-	"net/http"
-)
+import "context"
 
 // FirewallRuleResponse is the representation of a response of a firewall rule request.
 type FirewallRuleResponse struct {
@@ -20,6 +16,13 @@ type FirewallRuleResponseData struct {
 	*DataValidationError
 }
 
+// The `rest/firewallrule` endpoint managing the firewall rules of a [Site].
+var firewallRuleResource = restResource[FirewallRule, FirewallRuleResponse]{
+	path:       "rest/firewallrule",
+	name:       "firewall rule",
+	pluralName: "firewall rules",
+}
+
 // FirewallRule is the representation of a firewall rule.
 type FirewallRule struct {
 	// The rule ID.
@@ -247,41 +250,30 @@ type FirewallRule struct {
 // CreateFirewallRule creates a new firewall rule linked to this [Site] using the given firewall
 // rule data. It will return an error if the creation of the firewall rule failed.
 func (site *Site) CreateFirewallRule(firewallRule FirewallRule) (FirewallRuleResponse, error) {
-	endpointUrl := site.createEndpointUrl("rest/firewallrule", "")
-	responseData := FirewallRuleResponse{}
-
-	res, err := site.controller.execute(http.MethodPost, endpointUrl, firewallRule, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("creating firewall rule failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.CreateFirewallRuleWithContext(context.Background(), firewallRule)
+}
 
-	return responseData, nil
+// CreateFirewallRuleWithContext is the same as [Site.CreateFirewallRule] but uses the given context
+// for the request.
+func (site *Site) CreateFirewallRuleWithContext(
+	ctx context.Context,
+	firewallRule FirewallRule,
+) (FirewallRuleResponse, error) {
+	return firewallRuleResource.create(ctx, site, firewallRule)
 }
 
 // GetAllFirewallRules returns all firewall rules linked to this [Site].
 // It will return an error if it fails to fetch the firewall rules.
 func (site *Site) GetAllFirewallRules() (FirewallRuleResponse, error) {
-	endpointUrl := site.createEndpointUrl("rest/firewallrule", "")
-	responseData := FirewallRuleResponse{}
-
-	res, err := site.controller.execute(http.MethodGet, endpointUrl, nil, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("retreiving firewall rules failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.GetAllFirewallRulesWithContext(context.Background())
+}
 
-	return responseData, nil
+// GetAllFirewallRulesWithContext is the same as [Site.GetAllFirewallRules] but uses the given
+// context for the request.
+func (site *Site) GetAllFirewallRulesWithContext(
+	ctx context.Context,
+) (FirewallRuleResponse, error) {
+	return firewallRuleResource.getAll(ctx, site)
 }
 
 // GetFirewallRule returns the firewall rule linked to the given ID and this [Site].
@@ -289,21 +281,16 @@ func (site *Site) GetAllFirewallRules() (FirewallRuleResponse, error) {
 // with the given ID is present or the ID is invalid no error but a response with an empty data
 // array will be returned.
 func (site *Site) GetFirewallRule(id string) (FirewallRuleResponse, error) {
-	endpointUrl := site.createEndpointUrl("rest/firewallrule", id)
-	responseData := FirewallRuleResponse{}
-
-	res, err := site.controller.execute(http.MethodGet, endpointUrl, nil, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("retreiving firewall rule failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.GetFirewallRuleWithContext(context.Background(), id)
+}
 
-	return responseData, nil
+// GetFirewallRuleWithContext is the same as [Site.GetFirewallRule] but uses the given context for
+// the request.
+func (site *Site) GetFirewallRuleWithContext(
+	ctx context.Context,
+	id string,
+) (FirewallRuleResponse, error) {
+	return firewallRuleResource.get(ctx, site, id)
 }
 
 // UpdateFirewallRule updates the firewall rule linked to the given ID and this [Site] using the
@@ -312,39 +299,30 @@ func (site *Site) UpdateFirewallRule(
 	id string,
 	firewallRule FirewallRule,
 ) (FirewallRuleResponse, error) {
-	endpointUrl := site.createEndpointUrl("rest/firewallrule", id)
-	responseData := FirewallRuleResponse{}
-
-	res, err := site.controller.execute(http.MethodPut, endpointUrl, firewallRule, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("firewall rule update failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.UpdateFirewallRuleWithContext(context.Background(), id, firewallRule)
+}
 
-	return responseData, nil
+// UpdateFirewallRuleWithContext is the same as [Site.UpdateFirewallRule] but uses the given context
+// for the request.
+func (site *Site) UpdateFirewallRuleWithContext(
+	ctx context.Context,
+	id string,
+	firewallRule FirewallRule,
+) (FirewallRuleResponse, error) {
+	return firewallRuleResource.update(ctx, site, id, firewallRule)
 }
 
 // DeleteFirewallRule deletes the firewall rule linked to the given ID and this [Site].
 // It will return an error if the deletion of the firewall rule failed.
 func (site *Site) DeleteFirewallRule(id string) (FirewallRuleResponse, error) {
-	endpointUrl := site.createEndpointUrl("rest/firewallrule", id)
-	responseData := FirewallRuleResponse{}
-
-	res, err := site.controller.execute(http.MethodDelete, endpointUrl, nil, &responseData)
-	if err != nil {
-		return responseData, err
-	}
-
-	if res.StatusCode != 200 {
-		return responseData, errors.New(
-			fmt.Sprintf("deleting firewall rule failed with response code %d", res.StatusCode),
-		)
-	}
+	return site.DeleteFirewallRuleWithContext(context.Background(), id)
+}
 
-	return responseData, nil
+// DeleteFirewallRuleWithContext is the same as [Site.DeleteFirewallRule] but uses the given context
+// for the request.
+func (site *Site) DeleteFirewallRuleWithContext(
+	ctx context.Context,
+	id string,
+) (FirewallRuleResponse, error) {
+	return firewallRuleResource.delete(ctx, site, id)
 }
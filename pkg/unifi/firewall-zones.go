@@ -0,0 +1,223 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// FirewallModel is the model used by the firewall of a site.
+type FirewallModel string
+
+// The supported firewall models.
+const (
+	// FirewallModelRuleset is the firewall model based on rulesets (e.g. WAN_IN, LAN_IN), managed
+	// using firewall rules (see [FirewallRule]).
+	FirewallModelRuleset FirewallModel = "ruleset"
+	// FirewallModelZoneBased is the firewall model based on zones, managed using firewall zones
+	// (see [FirewallZone]) and firewall policies (see [FirewallPolicy]).
+	FirewallModelZoneBased FirewallModel = "zone-based"
+)
+
+// The `firewall/zone` endpoint (v2 API) managing the firewall zones of a [Site].
+var firewallZoneResource = v2Resource[FirewallZone]{
+	path:       "firewall/zone",
+	name:       "firewall zone",
+	pluralName: "firewall zones",
+}
+
+// siteFeatureMigration is the representation of a feature a site has been migrated to.
+type siteFeatureMigration struct {
+	Feature string `json:"feature"`
+}
+
+// FirewallZone is the representation of a firewall zone, a zone groups networks which share the
+// same firewall policies.
+// Firewall zones require UniFi Network application 9.0 or newer, see
+// [CapabilityZoneBasedFirewall].
+type FirewallZone struct {
+	// The zone ID.
+	Id string `json:"_id,omitempty"`
+	// The zone name.
+	Name string `json:"name,omitempty"`
+	// The key of a predefined zone, empty for custom zones, options:
+	//	- internal: The internal (LAN) networks.
+	//	- external: The internet connections.
+	//	- gateway: The gateway itself.
+	//	- vpn: The VPN networks.
+	//	- hotspot: The guest networks.
+	//	- dmz: The DMZ networks.
+	ZoneKey string `json:"zone_key,omitempty"`
+	// The IDs of the networks (see [Network]) in the zone, a network is part of a single zone.
+	NetworkIds []string `json:"network_ids"`
+	// Indicates whether the zone is a predefined zone.
+	DefaultZone bool `json:"default_zone,omitempty"`
+}
+
+// GetFirewallModel returns the firewall model used by this [Site], sites of UniFi Network
+// application 9.0 or newer use [FirewallModelZoneBased] once they have been migrated to zone based
+// firewalls. It will return an error if it fails to fetch the migrated features.
+func (site *Site) GetFirewallModel() (FirewallModel, error) {
+	return site.GetFirewallModelWithContext(context.Background())
+}
+
+// GetFirewallModelWithContext is the same as [Site.GetFirewallModel] but uses the given context
+// for the request.
+func (site *Site) GetFirewallModelWithContext(ctx context.Context) (FirewallModel, error) {
+	endpointUrl := site.createV2EndpointUrl("site-feature-migration", "")
+	var responseData []siteFeatureMigration
+
+	_, err := site.controller.execute(ctx, http.MethodGet, endpointUrl, nil, &responseData)
+	// Versions without support for zone based firewalls do not have the endpoint.
+	if errors.Is(err, NotFoundError) {
+		return FirewallModelRuleset, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("retreiving firewall model failed: %w", err)
+	}
+
+	for _, migration := range responseData {
+		if migration.Feature == "ZONE_BASED_FIREWALL" {
+			return FirewallModelZoneBased, nil
+		}
+	}
+	return FirewallModelRuleset, nil
+}
+
+// CreateFirewallZone creates a new custom firewall zone linked to this [Site] using the given
+// firewall zone data and returns the created firewall zone.
+// It will return an error if the creation of the firewall zone failed.
+func (site *Site) CreateFirewallZone(firewallZone FirewallZone) (FirewallZone, error) {
+	return site.CreateFirewallZoneWithContext(context.Background(), firewallZone)
+}
+
+// CreateFirewallZoneWithContext is the same as [Site.CreateFirewallZone] but uses the given
+// context for the request.
+func (site *Site) CreateFirewallZoneWithContext(
+	ctx context.Context,
+	firewallZone FirewallZone,
+) (FirewallZone, error) {
+	return firewallZoneResource.create(ctx, site, firewallZone)
+}
+
+// GetAllFirewallZones returns all firewall zones linked to this [Site].
+// It will return an error if it fails to fetch the firewall zones.
+func (site *Site) GetAllFirewallZones() ([]FirewallZone, error) {
+	return site.GetAllFirewallZonesWithContext(context.Background())
+}
+
+// GetAllFirewallZonesWithContext is the same as [Site.GetAllFirewallZones] but uses the given
+// context for the request.
+func (site *Site) GetAllFirewallZonesWithContext(ctx context.Context) ([]FirewallZone, error) {
+	return firewallZoneResource.getAll(ctx, site)
+}
+
+// UpdateFirewallZone updates the firewall zone linked to the given ID and this [Site] using the
+// given firewall zone data and returns the updated firewall zone. The given data is merged over
+// the current firewall zone, so its fields not included in [FirewallZone] are preserved.
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no firewall zone has the
+// given ID or an error if the update of the firewall zone failed.
+func (site *Site) UpdateFirewallZone(id string, firewallZone FirewallZone) (FirewallZone, error) {
+	return site.UpdateFirewallZoneWithContext(context.Background(), id, firewallZone)
+}
+
+// UpdateFirewallZoneWithContext is the same as [Site.UpdateFirewallZone] but uses the given
+// context for the request.
+func (site *Site) UpdateFirewallZoneWithContext(
+	ctx context.Context,
+	id string,
+	firewallZone FirewallZone,
+) (FirewallZone, error) {
+	return firewallZoneResource.update(ctx, site, id, firewallZone)
+}
+
+// AssignNetworksToFirewallZone replaces the networks of the firewall zone linked to the given ID
+// and this [Site] with the networks linked to the given network IDs (see [Network]). As a network
+// is part of a single zone, the given networks are first removed from the other zones containing
+// them. Only the networks of the zones are changed, their other settings (including the ones not
+// included in [FirewallZone]) are preserved. It returns the updated firewall zone.
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no firewall zone has the
+// given ID or an error if the update of a firewall zone failed. The updates are not applied
+// atomically: if an update fails, the zones updated before the failure keep their new networks,
+// so networks can be left without a zone.
+func (site *Site) AssignNetworksToFirewallZone(
+	id string,
+	networkIds []string,
+) (FirewallZone, error) {
+	return site.AssignNetworksToFirewallZoneWithContext(context.Background(), id, networkIds)
+}
+
+// AssignNetworksToFirewallZoneWithContext is the same as [Site.AssignNetworksToFirewallZone] but
+// uses the given context for the requests.
+func (site *Site) AssignNetworksToFirewallZoneWithContext(
+	ctx context.Context,
+	id string,
+	networkIds []string,
+) (FirewallZone, error) {
+	firewallZones, err := firewallZoneResource.getAllRaw(ctx, site)
+	if err != nil {
+		return FirewallZone{}, err
+	}
+
+	index := slices.IndexFunc(firewallZones, func(firewallZone rawObject[FirewallZone]) bool {
+		return firewallZone.object.Id == id
+	})
+	if index < 0 {
+		return FirewallZone{}, fmt.Errorf("%w: no firewall zone with ID %q", NotFoundError, id)
+	}
+
+	// Remove the networks from their previous zone.
+	for _, firewallZone := range firewallZones {
+		if firewallZone.object.Id == id {
+			continue
+		}
+
+		remainingNetworkIds := slices.DeleteFunc(
+			slices.Clone(firewallZone.object.NetworkIds),
+			func(networkId string) bool {
+				return slices.Contains(networkIds, networkId)
+			},
+		)
+		if len(remainingNetworkIds) == len(firewallZone.object.NetworkIds) {
+			continue
+		}
+
+		err = firewallZone.set("network_ids", remainingNetworkIds)
+		if err != nil {
+			return FirewallZone{}, err
+		}
+		_, err = firewallZoneResource.updateRaw(
+			ctx,
+			site,
+			firewallZone.object.Id,
+			firewallZone.fields,
+		)
+		if err != nil {
+			return FirewallZone{}, err
+		}
+	}
+
+	if networkIds == nil {
+		networkIds = []string{}
+	}
+	firewallZone := firewallZones[index]
+	err = firewallZone.set("network_ids", networkIds)
+	if err != nil {
+		return FirewallZone{}, err
+	}
+	return firewallZoneResource.updateRaw(ctx, site, id, firewallZone.fields)
+}
+
+// DeleteFirewallZone deletes the custom firewall zone linked to the given ID and this [Site].
+// It will return an error if the deletion of the firewall zone failed.
+func (site *Site) DeleteFirewallZone(id string) error {
+	return site.DeleteFirewallZoneWithContext(context.Background(), id)
+}
+
+// DeleteFirewallZoneWithContext is the same as [Site.DeleteFirewallZone] but uses the given
+// context for the request.
+func (site *Site) DeleteFirewallZoneWithContext(ctx context.Context, id string) error {
+	return firewallZoneResource.delete(ctx, site, id)
+}
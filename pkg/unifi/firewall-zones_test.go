@@ -0,0 +1,71 @@
+package unifi
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// Returns the network IDs of the given firewall zone object of a [v2TestStore].
+func zoneNetworkIds(object map[string]any) []string {
+	networkIds := []string{}
+	values, _ := object["network_ids"].([]any)
+	for _, value := range values {
+		networkIds = append(networkIds, value.(string))
+	}
+	return networkIds
+}
+
+func TestAssignNetworksToFirewallZoneMovesNetworks(t *testing.T) {
+	store := &v2TestStore{objects: []map[string]any{
+		{"_id": "internal", "name": "Internal", "network_ids": []string{"lan", "iot"}},
+		{"_id": "hotspot", "name": "Hotspot", "network_ids": []string{"guest"}},
+		{"_id": "custom", "name": "Custom", "network_ids": []string{}, "attr_no_edit": false},
+	}}
+	server := newTestServer(t)
+	server.handler = store.handler(t, "firewall/zone")
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	firewallZone, err := site.AssignNetworksToFirewallZone("custom", []string{"iot", "guest"})
+	if err != nil {
+		t.Fatalf("assigning networks failed: %s", err)
+	}
+	if !slices.Equal(firewallZone.NetworkIds, []string{"iot", "guest"}) {
+		t.Errorf("expected returned zone to contain iot and guest, got %v", firewallZone.NetworkIds)
+	}
+
+	expected := map[string][]string{
+		"internal": {"lan"},
+		"hotspot":  {},
+		"custom":   {"iot", "guest"},
+	}
+	for id, expectedNetworkIds := range expected {
+		networkIds := zoneNetworkIds(store.object(id))
+		if !slices.Equal(networkIds, expectedNetworkIds) {
+			t.Errorf("expected zone %s to contain %v, got %v", id, expectedNetworkIds, networkIds)
+		}
+	}
+	if value, ok := store.object("custom")["attr_no_edit"]; !ok || value != false {
+		t.Errorf("expected unknown field to be preserved, got %v", value)
+	}
+	if store.updates[len(store.updates)-1] != "custom" {
+		t.Errorf("expected the target zone to be updated last, got %v", store.updates)
+	}
+}
+
+func TestAssignNetworksToFirewallZoneUnknownId(t *testing.T) {
+	store := &v2TestStore{objects: []map[string]any{
+		{"_id": "internal", "network_ids": []string{"lan"}},
+	}}
+	server := newTestServer(t)
+	server.handler = store.handler(t, "firewall/zone")
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	_, err := site.AssignNetworksToFirewallZone("unknown", []string{"lan"})
+	if !errors.Is(err, NotFoundError) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if len(store.updates) != 0 {
+		t.Errorf("expected no updates, got %v", store.updates)
+	}
+}
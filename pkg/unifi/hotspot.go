@@ -0,0 +1,360 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+)
+
+// GuestResponse is the representation of a response of a guest request.
+type GuestResponse struct {
+	Meta Meta                `json:"meta"`
+	Data []GuestResponseData `json:"data"`
+}
+
+// GuestResponseData is the representation of the data inside the data array of the
+// [GuestResponse]. It contains either [Guest] or [DataValidationError] based on whether the
+// request succeeded.
+type GuestResponseData struct {
+	*Guest
+	*DataValidationError
+}
+
+// Guest is the representation of an authorized session of a guest client of the hotspot.
+type Guest struct {
+	// The guest session ID.
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this guest session.
+	SiteId string `json:"site_id,omitempty"`
+	// The MAC address of the guest client.
+	Mac string `json:"mac,omitempty"`
+	// The MAC address of the access point the guest client connected to.
+	ApMac string `json:"ap_mac,omitempty"`
+	// The method used to authorize the guest, e.g. api, voucher, password, none.
+	AuthorizedBy string `json:"authorized_by,omitempty"`
+	// The ID of the voucher used to authorize the guest.
+	VoucherId string `json:"voucher_id,omitempty"`
+	// The code of the voucher used to authorize the guest.
+	VoucherCode string `json:"voucher_code,omitempty"`
+	// The time (unix timestamp) at which the authorization started.
+	Start int64 `json:"start,omitempty"`
+	// The time (unix timestamp) at which the authorization ends.
+	End int64 `json:"end,omitempty"`
+	// The duration of the authorization in minutes.
+	Duration int `json:"duration,omitempty"`
+	// Indicates whether the authorization has expired.
+	Expired bool `json:"expired,omitempty"`
+	// The upload limit in kbps.
+	QosRateMaxUp int `json:"qos_rate_max_up,omitempty"`
+	// The download limit in kbps.
+	QosRateMaxDown int `json:"qos_rate_max_down,omitempty"`
+	// The data limit in MB.
+	QosUsageQuota int `json:"qos_usage_quota,omitempty"`
+	// The number of bytes sent to the guest client.
+	TxBytes int64 `json:"tx_bytes,omitempty"`
+	// The number of bytes received from the guest client.
+	RxBytes int64 `json:"rx_bytes,omitempty"`
+}
+
+// VoucherResponse is the representation of a response of a voucher request.
+type VoucherResponse struct {
+	Meta Meta                  `json:"meta"`
+	Data []VoucherResponseData `json:"data"`
+}
+
+// VoucherResponseData is the representation of the data inside the data array of the
+// [VoucherResponse]. It contains either [Voucher] or [DataValidationError] based on whether the
+// request succeeded.
+type VoucherResponseData struct {
+	*Voucher
+	*DataValidationError
+}
+
+// Voucher is the representation of a hotspot voucher.
+type Voucher struct {
+	// The voucher ID.
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this voucher.
+	SiteId string `json:"site_id,omitempty"`
+	// The voucher code entered by the guest (without dash) e.g. "1234567890".
+	Code string `json:"code,omitempty"`
+	// The time (unix timestamp) at which the voucher was created.
+	CreateTime int64 `json:"create_time,omitempty"`
+	// The duration of the authorization in minutes.
+	Duration int `json:"duration,omitempty"`
+	// The number of times the voucher can be used, 0 for unlimited.
+	Quota int `json:"quota,omitempty"`
+	// The number of times the voucher has been used.
+	Used int `json:"used,omitempty"`
+	// The note of the voucher.
+	Note string `json:"note,omitempty"`
+	// The upload limit in kbps.
+	QosRateMaxUp int `json:"qos_rate_max_up,omitempty"`
+	// The download limit in kbps.
+	QosRateMaxDown int `json:"qos_rate_max_down,omitempty"`
+	// The data limit in MB.
+	QosUsageQuota int `json:"qos_usage_quota,omitempty"`
+	// The status of the voucher e.g. VALID_ONE, VALID_MULTI, USED_MULTIPLE.
+	Status string `json:"status,omitempty"`
+	// The time (unix timestamp) at which the voucher expires.
+	StatusExpires int64 `json:"status_expires,omitempty"`
+	// The name of the admin that created the voucher.
+	AdminName string `json:"admin_name,omitempty"`
+}
+
+// GuestAuthorization contains the limits applied to an authorized guest, see
+// [Site.AuthorizeGuest].
+type GuestAuthorization struct {
+	// The duration of the authorization (rounded up to minutes).
+	Duration time.Duration
+	// The upload limit in kbps, 0 for no limit.
+	UploadKbps int
+	// The download limit in kbps, 0 for no limit.
+	DownloadKbps int
+	// The data limit in MB, 0 for no limit.
+	DataLimitMegabytes int
+	// The MAC address of the access point the guest is connected to (optional).
+	ApMac string
+}
+
+// VoucherOptions contains the settings of newly created vouchers, see [Site.CreateVouchers].
+type VoucherOptions struct {
+	// The number of vouchers to create.
+	Count int
+	// The duration of the authorization of a guest using a voucher (rounded up to minutes).
+	Duration time.Duration
+	// The number of times a voucher can be used, 0 for a single use (default) and a negative
+	// number for unlimited uses.
+	Uses int
+	// The note of the vouchers.
+	Note string
+	// The upload limit in kbps, 0 for no limit.
+	UploadKbps int
+	// The download limit in kbps, 0 for no limit.
+	DownloadKbps int
+	// The data limit in MB, 0 for no limit.
+	DataLimitMegabytes int
+}
+
+// guestAuthorizationCommand is the representation of the body of a guest authorization command.
+type guestAuthorizationCommand struct {
+	Cmd     string `json:"cmd"`
+	Mac     string `json:"mac"`
+	Minutes int    `json:"minutes"`
+	Up      int    `json:"up,omitempty"`
+	Down    int    `json:"down,omitempty"`
+	Bytes   int    `json:"bytes,omitempty"`
+	ApMac   string `json:"ap_mac,omitempty"`
+}
+
+// createVoucherCommand is the representation of the body of a create voucher command.
+type createVoucherCommand struct {
+	Cmd    string `json:"cmd"`
+	N      int    `json:"n"`
+	Expire int    `json:"expire"`
+	Quota  int    `json:"quota"`
+	Note   string `json:"note,omitempty"`
+	Up     int    `json:"up,omitempty"`
+	Down   int    `json:"down,omitempty"`
+	Bytes  int    `json:"bytes,omitempty"`
+}
+
+// deleteVoucherCommand is the representation of the body of a delete voucher command.
+type deleteVoucherCommand struct {
+	Cmd string `json:"cmd"`
+	Id  string `json:"_id"`
+}
+
+// AuthorizeGuest authorizes the guest client with the given MAC address on the hotspot of this
+// [Site] using the given authorization limits.
+// It will return an error if authorizing the guest failed.
+func (site *Site) AuthorizeGuest(
+	mac string,
+	authorization GuestAuthorization,
+) (ClientResponse, error) {
+	return site.AuthorizeGuestWithContext(context.Background(), mac, authorization)
+}
+
+// AuthorizeGuestWithContext is the same as [Site.AuthorizeGuest] but uses the given context for
+// the request.
+func (site *Site) AuthorizeGuestWithContext(
+	ctx context.Context,
+	mac string,
+	authorization GuestAuthorization,
+) (ClientResponse, error) {
+	if authorization.Duration <= 0 {
+		return ClientResponse{}, errors.New("authorizing guest failed: duration must be positive")
+	}
+
+	return site.executeStationManagerCommand(
+		ctx,
+		guestAuthorizationCommand{
+			Cmd:     "authorize-guest",
+			Mac:     mac,
+			Minutes: durationInMinutes(authorization.Duration),
+			Up:      authorization.UploadKbps,
+			Down:    authorization.DownloadKbps,
+			Bytes:   authorization.DataLimitMegabytes,
+			ApMac:   authorization.ApMac,
+		},
+		"authorizing guest",
+	)
+}
+
+// UnauthorizeGuest revokes the authorization of the guest client with the given MAC address on
+// the hotspot of this [Site]. It will return an error if unauthorizing the guest failed.
+func (site *Site) UnauthorizeGuest(mac string) (ClientResponse, error) {
+	return site.UnauthorizeGuestWithContext(context.Background(), mac)
+}
+
+// UnauthorizeGuestWithContext is the same as [Site.UnauthorizeGuest] but uses the given context
+// for the request.
+func (site *Site) UnauthorizeGuestWithContext(
+	ctx context.Context,
+	mac string,
+) (ClientResponse, error) {
+	return site.executeStationManagerCommand(
+		ctx,
+		stationManagerCommand{Cmd: "unauthorize-guest", Mac: mac},
+		"unauthorizing guest",
+	)
+}
+
+// GetAllGuests returns the guest sessions of the hotspot of this [Site] that were active within
+// the given duration (rounded up to hours).
+// It will return an error if it fails to fetch the guest sessions.
+func (site *Site) GetAllGuests(within time.Duration) (GuestResponse, error) {
+	return site.GetAllGuestsWithContext(context.Background(), within)
+}
+
+// GetAllGuestsWithContext is the same as [Site.GetAllGuests] but uses the given context for the
+// request.
+func (site *Site) GetAllGuestsWithContext(
+	ctx context.Context,
+	within time.Duration,
+) (GuestResponse, error) {
+	endpointUrl := site.createEndpointUrl("stat/guest", "")
+	responseData := GuestResponse{}
+	request := map[string]int{"within": int(math.Ceil(within.Hours()))}
+
+	_, err := site.controller.execute(ctx, http.MethodPost, endpointUrl, request, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("retreiving guests failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// CreateVouchers creates new hotspot vouchers linked to this [Site] using the given options and
+// returns the created vouchers. The controller only returns the creation time (in seconds) of the
+// vouchers, the vouchers are fetched using it: vouchers created by another request in the same
+// second are returned as well.
+// It will return an error if the creation of the vouchers failed.
+func (site *Site) CreateVouchers(options VoucherOptions) (VoucherResponse, error) {
+	return site.CreateVouchersWithContext(context.Background(), options)
+}
+
+// CreateVouchersWithContext is the same as [Site.CreateVouchers] but uses the given context for
+// the requests.
+func (site *Site) CreateVouchersWithContext(
+	ctx context.Context,
+	options VoucherOptions,
+) (VoucherResponse, error) {
+	if options.Count <= 0 || options.Duration <= 0 {
+		return VoucherResponse{}, errors.New(
+			"creating vouchers failed: count and duration must be positive",
+		)
+	}
+
+	// The controller uses a quota of 0 for unlimited uses.
+	quota := options.Uses
+	if quota == 0 {
+		quota = 1
+	} else if quota < 0 {
+		quota = 0
+	}
+
+	// The response only contains the creation time, which is used to fetch the created vouchers.
+	responseData := VoucherResponse{}
+	command := createVoucherCommand{
+		Cmd:    "create-voucher",
+		N:      options.Count,
+		Expire: durationInMinutes(options.Duration),
+		Quota:  quota,
+		Note:   options.Note,
+		Up:     options.UploadKbps,
+		Down:   options.DownloadKbps,
+		Bytes:  options.DataLimitMegabytes,
+	}
+
+	err := site.executeCommand(ctx, "hotspot", command, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("creating vouchers failed: %w", err)
+	}
+	if len(responseData.Data) == 0 || responseData.Data[0].Voucher == nil {
+		return responseData, errors.New("creating vouchers failed: empty response")
+	}
+
+	return site.getVouchers(ctx, responseData.Data[0].CreateTime)
+}
+
+// GetAllVouchers returns all hotspot vouchers linked to this [Site].
+// It will return an error if it fails to fetch the vouchers.
+func (site *Site) GetAllVouchers() (VoucherResponse, error) {
+	return site.GetAllVouchersWithContext(context.Background())
+}
+
+// GetAllVouchersWithContext is the same as [Site.GetAllVouchers] but uses the given context for
+// the request.
+func (site *Site) GetAllVouchersWithContext(ctx context.Context) (VoucherResponse, error) {
+	return site.getVouchers(ctx, 0)
+}
+
+// Returns the vouchers of the [Site] created at the given time (unix timestamp) or all vouchers if
+// createTime is 0. It will return an error if it fails to fetch the vouchers.
+func (site *Site) getVouchers(ctx context.Context, createTime int64) (VoucherResponse, error) {
+	endpointUrl := site.createEndpointUrl("stat/voucher", "")
+	responseData := VoucherResponse{}
+	var request any
+	if createTime != 0 {
+		request = map[string]int64{"create_time": createTime}
+	}
+
+	_, err := site.controller.execute(ctx, http.MethodPost, endpointUrl, request, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("retreiving vouchers failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// RevokeVoucher deletes the hotspot voucher linked to the given ID and this [Site], the voucher
+// can no longer be used. It will return an error if the deletion of the voucher failed.
+func (site *Site) RevokeVoucher(id string) (VoucherResponse, error) {
+	return site.RevokeVoucherWithContext(context.Background(), id)
+}
+
+// RevokeVoucherWithContext is the same as [Site.RevokeVoucher] but uses the given context for the
+// request.
+func (site *Site) RevokeVoucherWithContext(
+	ctx context.Context,
+	id string,
+) (VoucherResponse, error) {
+	responseData := VoucherResponse{}
+	command := deleteVoucherCommand{Cmd: "delete-voucher", Id: id}
+
+	err := site.executeCommand(ctx, "hotspot", command, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("deleting voucher failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// Returns the given duration in minutes, rounded up.
+func durationInMinutes(duration time.Duration) int {
+	return int(math.Ceil(duration.Minutes()))
+}
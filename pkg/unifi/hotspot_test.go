@@ -0,0 +1,69 @@
+package unifi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCreateVouchers(t *testing.T) {
+	tests := []struct {
+		name  string
+		uses  int
+		quota float64
+	}{
+		{name: "single use", uses: 0, quota: 1},
+		{name: "multiple uses", uses: 5, quota: 5},
+		{name: "unlimited uses", uses: -1, quota: 0},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+			var command map[string]any
+			var lookup string
+			server.handler = func(w http.ResponseWriter, r *http.Request) {
+				byteArray, _ := io.ReadAll(r.Body)
+				switch r.URL.Path {
+				case "/api/s/default/cmd/hotspot":
+					if err := json.Unmarshal(byteArray, &command); err != nil {
+						t.Errorf("parsing request body %q failed: %s", byteArray, err)
+					}
+					_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},` +
+						`"data":[{"create_time":1700000000}]}`))
+				case "/api/s/default/stat/voucher":
+					lookup = string(byteArray)
+					_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[` +
+						`{"_id":"a","code":"1234567890","create_time":1700000000},` +
+						`{"_id":"b","code":"0987654321","create_time":1700000000}]}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}
+			site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+			vouchers, err := site.CreateVouchers(VoucherOptions{
+				Count:    2,
+				Duration: 90 * time.Second,
+				Uses:     test.uses,
+			})
+			if err != nil {
+				t.Fatalf("creating vouchers failed: %s", err)
+			}
+			if command["cmd"] != "create-voucher" || command["n"] != 2.0 ||
+				command["expire"] != 2.0 || command["quota"] != test.quota {
+				t.Errorf("unexpected create command %v", command)
+			}
+			if lookup != `{"create_time":1700000000}` {
+				t.Errorf("expected vouchers to be fetched by creation time, got %s", lookup)
+			}
+			if len(vouchers.Data) != 2 || vouchers.Data[0].Code != "1234567890" {
+				t.Errorf("expected the 2 created vouchers, got %+v", vouchers.Data)
+			}
+			if requests := server.requests.Load(); requests != 2 {
+				t.Errorf("expected 2 requests, got %d", requests)
+			}
+		})
+	}
+}
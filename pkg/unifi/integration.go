@@ -0,0 +1,355 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// The maximum number of items the integration API returns per page.
+const integrationPageLimit = 200
+
+// IntegrationPage is the representation of a page of items returned by the integration API.
+type IntegrationPage[T any] struct {
+	// The index of the first item of the page.
+	Offset int `json:"offset"`
+	// The maximum number of items of the page.
+	Limit int `json:"limit"`
+	// The number of items of the page.
+	Count int `json:"count"`
+	// The total number of items over all pages.
+	TotalCount int `json:"totalCount"`
+	// The items of the page.
+	Data []T `json:"data"`
+}
+
+// IntegrationInfo is the representation of the general information returned by the integration
+// API.
+type IntegrationInfo struct {
+	// The version of the UniFi Network application.
+	ApplicationVersion string `json:"applicationVersion,omitempty"`
+}
+
+// IntegrationSite is the representation of a site returned by the integration API.
+type IntegrationSite struct {
+	// The site ID used by the integration API.
+	Id string `json:"id,omitempty"`
+	// The site name as used by the other API endpoints (see [Controller.CreateSite]).
+	InternalReference string `json:"internalReference,omitempty"`
+	// The site display name.
+	Name string `json:"name,omitempty"`
+}
+
+// IntegrationDevice is the representation of an adopted UniFi device returned by the integration
+// API when listing devices.
+type IntegrationDevice struct {
+	// The device ID.
+	Id string `json:"id,omitempty"`
+	// The device name.
+	Name string `json:"name,omitempty"`
+	// The device model e.g. "UDM Pro", "USW 24 PoE".
+	Model string `json:"model,omitempty"`
+	// The device MAC address.
+	MacAddress string `json:"macAddress,omitempty"`
+	// The device IP address.
+	IpAddress string `json:"ipAddress,omitempty"`
+	// The device state e.g. ONLINE, OFFLINE, PENDING_ADOPTION, UPDATING, GETTING_READY, ADOPTING,
+	// DELETING, CONNECTION_INTERRUPTED, ISOLATED.
+	State string `json:"state,omitempty"`
+	// The device features e.g. switching, accessPoint.
+	Features []string `json:"features,omitempty"`
+	// The device interface types e.g. ports, radios.
+	Interfaces []string `json:"interfaces,omitempty"`
+}
+
+// IntegrationDeviceDetails is the representation of an adopted UniFi device returned by the
+// integration API when retrieving a single device.
+type IntegrationDeviceDetails struct {
+	// The device ID.
+	Id string `json:"id,omitempty"`
+	// The device name.
+	Name string `json:"name,omitempty"`
+	// The device model e.g. "UDM Pro", "USW 24 PoE".
+	Model string `json:"model,omitempty"`
+	// Indicates whether the device is supported by the UniFi Network application.
+	Supported bool `json:"supported,omitempty"`
+	// The device MAC address.
+	MacAddress string `json:"macAddress,omitempty"`
+	// The device IP address.
+	IpAddress string `json:"ipAddress,omitempty"`
+	// The device state, see [IntegrationDevice] for the possible states.
+	State string `json:"state,omitempty"`
+	// The device firmware version.
+	FirmwareVersion string `json:"firmwareVersion,omitempty"`
+	// Indicates whether a firmware update is available.
+	FirmwareUpdatable bool `json:"firmwareUpdatable,omitempty"`
+	// The time at which the device was adopted.
+	AdoptedAt time.Time `json:"adoptedAt,omitempty"`
+	// The time at which the device was last provisioned.
+	ProvisionedAt time.Time `json:"provisionedAt,omitempty"`
+	// The ID of the configuration currently applied to the device.
+	ConfigurationId string `json:"configurationId,omitempty"`
+	// The uplink of the device.
+	Uplink struct {
+		// The ID of the device this device is connected to.
+		DeviceId string `json:"deviceId,omitempty"`
+	} `json:"uplink,omitempty"`
+	// The device features by feature name (e.g. switching, accessPoint), the structure of each
+	// feature depends on the feature.
+	Features map[string]json.RawMessage `json:"features,omitempty"`
+	// The device interfaces by interface type (e.g. ports, radios), the structure of each interface
+	// type depends on the interface type.
+	Interfaces map[string]json.RawMessage `json:"interfaces,omitempty"`
+}
+
+// IntegrationDeviceStatistics is the representation of the latest statistics of an adopted UniFi
+// device returned by the integration API.
+type IntegrationDeviceStatistics struct {
+	// The device uptime in seconds.
+	UptimeSec int64 `json:"uptimeSec,omitempty"`
+	// The time at which the last heartbeat was received.
+	LastHeartbeatAt time.Time `json:"lastHeartbeatAt,omitempty"`
+	// The time at which the next heartbeat is expected.
+	NextHeartbeatAt time.Time `json:"nextHeartbeatAt,omitempty"`
+	// The load average over the last minute.
+	LoadAverage1Min float64 `json:"loadAverage1Min,omitempty"`
+	// The load average over the last 5 minutes.
+	LoadAverage5Min float64 `json:"loadAverage5Min,omitempty"`
+	// The load average over the last 15 minutes.
+	LoadAverage15Min float64 `json:"loadAverage15Min,omitempty"`
+	// The CPU utilization in percent.
+	CpuUtilizationPct float64 `json:"cpuUtilizationPct,omitempty"`
+	// The memory utilization in percent.
+	MemoryUtilizationPct float64 `json:"memoryUtilizationPct,omitempty"`
+	// The uplink statistics.
+	Uplink struct {
+		// The transmit rate in bits per second.
+		TxRateBps int64 `json:"txRateBps,omitempty"`
+		// The receive rate in bits per second.
+		RxRateBps int64 `json:"rxRateBps,omitempty"`
+	} `json:"uplink,omitempty"`
+	// The interface statistics by interface type (e.g. radios), the structure of each interface
+	// type depends on the interface type.
+	Interfaces map[string]json.RawMessage `json:"interfaces,omitempty"`
+}
+
+// IntegrationClient is the representation of a connected client returned by the integration API.
+type IntegrationClient struct {
+	// The client type, options:
+	//	- WIRED: A client connected using a cable.
+	//	- WIRELESS: A client connected using Wi-Fi.
+	//	- VPN: A client connected using a VPN.
+	//	- TELEPORT: A client connected using Teleport.
+	Type string `json:"type,omitempty"`
+	// The client ID.
+	Id string `json:"id,omitempty"`
+	// The client name.
+	Name string `json:"name,omitempty"`
+	// The time at which the client connected.
+	ConnectedAt time.Time `json:"connectedAt,omitempty"`
+	// The client IP address.
+	IpAddress string `json:"ipAddress,omitempty"`
+	// The client MAC address (not set for VPN and Teleport clients).
+	MacAddress string `json:"macAddress,omitempty"`
+	// The ID of the device the client is connected to.
+	UplinkDeviceId string `json:"uplinkDeviceId,omitempty"`
+}
+
+// Returns the integration API endpoint for the given path.
+func (controller *Controller) createIntegrationEndpointUrl(path string) string {
+	return fmt.Sprintf("%s/proxy/network/integration/v1/%s", controller.baseUrl, path)
+}
+
+// Retrieves all pages of the integration API endpoint with the given path and returns the
+// combined items. It will return an error if it fails to fetch any of the pages.
+func getAllIntegrationPages[T any](
+	ctx context.Context,
+	controller *Controller,
+	path string,
+) ([]T, error) {
+	var items []T
+	for offset := 0; ; {
+		query := url.Values{}
+		query.Set("offset", strconv.Itoa(offset))
+		query.Set("limit", strconv.Itoa(integrationPageLimit))
+		endpointUrl := fmt.Sprintf(
+			"%s?%s",
+			controller.createIntegrationEndpointUrl(path),
+			query.Encode(),
+		)
+
+		page := IntegrationPage[T]{}
+		_, err := controller.execute(ctx, http.MethodGet, endpointUrl, nil, &page)
+		if err != nil {
+			return items, err
+		}
+
+		items = append(items, page.Data...)
+		offset += page.Count
+		if page.Count == 0 || offset >= page.TotalCount {
+			return items, nil
+		}
+	}
+}
+
+// GetIntegrationInfo returns the general information of the UniFi Network application using the
+// integration API. It will return an error if it fails to fetch the information.
+func (controller *Controller) GetIntegrationInfo() (IntegrationInfo, error) {
+	return controller.GetIntegrationInfoWithContext(context.Background())
+}
+
+// GetIntegrationInfoWithContext is the same as [Controller.GetIntegrationInfo] but uses the given
+// context for the request.
+func (controller *Controller) GetIntegrationInfoWithContext(
+	ctx context.Context,
+) (IntegrationInfo, error) {
+	endpointUrl := controller.createIntegrationEndpointUrl("info")
+	responseData := IntegrationInfo{}
+
+	_, err := controller.execute(ctx, http.MethodGet, endpointUrl, nil, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("retreiving integration info failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// GetAllIntegrationSites returns all sites the API key has access to using the integration API.
+// It will return an error if it fails to fetch the sites.
+func (controller *Controller) GetAllIntegrationSites() ([]IntegrationSite, error) {
+	return controller.GetAllIntegrationSitesWithContext(context.Background())
+}
+
+// GetAllIntegrationSitesWithContext is the same as [Controller.GetAllIntegrationSites] but uses the
+// given context for the requests.
+func (controller *Controller) GetAllIntegrationSitesWithContext(
+	ctx context.Context,
+) ([]IntegrationSite, error) {
+	sites, err := getAllIntegrationPages[IntegrationSite](ctx, controller, "sites")
+	if err != nil {
+		return sites, fmt.Errorf("retreiving integration sites failed: %w", err)
+	}
+
+	return sites, nil
+}
+
+// GetAllIntegrationDevices returns all adopted devices of the site with the given (integration
+// API) site ID using the integration API. It will return an error if it fails to fetch the
+// devices.
+func (controller *Controller) GetAllIntegrationDevices(siteId string) ([]IntegrationDevice, error) {
+	return controller.GetAllIntegrationDevicesWithContext(context.Background(), siteId)
+}
+
+// GetAllIntegrationDevicesWithContext is the same as [Controller.GetAllIntegrationDevices] but
+// uses the given context for the requests.
+func (controller *Controller) GetAllIntegrationDevicesWithContext(
+	ctx context.Context,
+	siteId string,
+) ([]IntegrationDevice, error) {
+	devices, err := getAllIntegrationPages[IntegrationDevice](
+		ctx,
+		controller,
+		fmt.Sprintf("sites/%s/devices", url.PathEscape(siteId)),
+	)
+	if err != nil {
+		return devices, fmt.Errorf("retreiving integration devices failed: %w", err)
+	}
+
+	return devices, nil
+}
+
+// GetIntegrationDevice returns the adopted device linked to the given device ID and (integration
+// API) site ID using the integration API. It will return an error if it fails to fetch the device.
+func (controller *Controller) GetIntegrationDevice(
+	siteId string,
+	deviceId string,
+) (IntegrationDeviceDetails, error) {
+	return controller.GetIntegrationDeviceWithContext(context.Background(), siteId, deviceId)
+}
+
+// GetIntegrationDeviceWithContext is the same as [Controller.GetIntegrationDevice] but uses the
+// given context for the request.
+func (controller *Controller) GetIntegrationDeviceWithContext(
+	ctx context.Context,
+	siteId string,
+	deviceId string,
+) (IntegrationDeviceDetails, error) {
+	endpointUrl := controller.createIntegrationEndpointUrl(
+		fmt.Sprintf("sites/%s/devices/%s", url.PathEscape(siteId), url.PathEscape(deviceId)),
+	)
+	responseData := IntegrationDeviceDetails{}
+
+	_, err := controller.execute(ctx, http.MethodGet, endpointUrl, nil, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("retreiving integration device failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// GetIntegrationDeviceStatistics returns the latest statistics of the adopted device linked to the
+// given device ID and (integration API) site ID using the integration API.
+// It will return an error if it fails to fetch the statistics.
+func (controller *Controller) GetIntegrationDeviceStatistics(
+	siteId string,
+	deviceId string,
+) (IntegrationDeviceStatistics, error) {
+	return controller.GetIntegrationDeviceStatisticsWithContext(
+		context.Background(),
+		siteId,
+		deviceId,
+	)
+}
+
+// GetIntegrationDeviceStatisticsWithContext is the same as
+// [Controller.GetIntegrationDeviceStatistics] but uses the given context for the request.
+func (controller *Controller) GetIntegrationDeviceStatisticsWithContext(
+	ctx context.Context,
+	siteId string,
+	deviceId string,
+) (IntegrationDeviceStatistics, error) {
+	endpointUrl := controller.createIntegrationEndpointUrl(
+		fmt.Sprintf(
+			"sites/%s/devices/%s/statistics/latest",
+			url.PathEscape(siteId),
+			url.PathEscape(deviceId),
+		),
+	)
+	responseData := IntegrationDeviceStatistics{}
+
+	_, err := controller.execute(ctx, http.MethodGet, endpointUrl, nil, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("retreiving integration device statistics failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// GetAllIntegrationClients returns all connected clients of the site with the given (integration
+// API) site ID using the integration API. It will return an error if it fails to fetch the
+// clients.
+func (controller *Controller) GetAllIntegrationClients(siteId string) ([]IntegrationClient, error) {
+	return controller.GetAllIntegrationClientsWithContext(context.Background(), siteId)
+}
+
+// GetAllIntegrationClientsWithContext is the same as [Controller.GetAllIntegrationClients] but
+// uses the given context for the requests.
+func (controller *Controller) GetAllIntegrationClientsWithContext(
+	ctx context.Context,
+	siteId string,
+) ([]IntegrationClient, error) {
+	clients, err := getAllIntegrationPages[IntegrationClient](
+		ctx,
+		controller,
+		fmt.Sprintf("sites/%s/clients", url.PathEscape(siteId)),
+	)
+	if err != nil {
+		return clients, fmt.Errorf("retreiving integration clients failed: %w", err)
+	}
+
+	return clients, nil
+}
@@ -0,0 +1,234 @@
+package unifi
+
+import "context"
+
+// NetworkResponse is the representation of a response of a network request.
+type NetworkResponse struct {
+	Meta Meta                  `json:"meta"`
+	Data []NetworkResponseData `json:"data"`
+}
+
+// NetworkResponseData is the representation of the data inside the data array of the
+// [NetworkResponse]. It contains either [Network] or [DataValidationError] based on whether the
+// request succeeded.
+type NetworkResponseData struct {
+	*Network
+	*DataValidationError
+}
+
+// The `rest/networkconf` endpoint managing the networks of a [Site].
+var networkResource = restResource[Network, NetworkResponse]{
+	path:       "rest/networkconf",
+	name:       "network",
+	pluralName: "networks",
+}
+
+// Network is the representation of a network (LAN, VLAN, WAN or VPN).
+type Network struct {
+	// The network ID, this is the ID referenced by other objects e.g.
+	// [FirewallRule.SrcNetworkConfId].
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this network.
+	SiteId string `json:"site_id,omitempty"`
+	// The name of the network.
+	Name string `json:"name,omitempty"`
+	// The purpose of the network, options:
+	//	- corporate: A LAN network routed by the gateway.
+	//	- guest: A LAN network for guests, isolated from the other networks.
+	//	- wan: An internet connection.
+	//	- vlan-only: A VLAN that is not routed by the gateway (no subnet or DHCP).
+	//	- remote-user-vpn: A VPN server for remote users.
+	//	- site-vpn: A site-to-site VPN.
+	Purpose string `json:"purpose,omitempty"`
+	// The group of the network, options:
+	//	- LAN: A local network.
+	//	- WAN: The primary internet connection.
+	//	- WAN2: The secondary internet connection.
+	NetworkGroup string `json:"networkgroup,omitempty"`
+	// Indicates whether the network is active.
+	Enabled bool `json:"enabled,omitempty"`
+	// Indicates whether the network uses a VLAN.
+	VlanEnabled bool `json:"vlan_enabled,omitempty"`
+	// The VLAN ID (2-4009) of the network, used when VlanEnabled is true.
+	Vlan int `json:"vlan,omitempty"`
+	// The IPv4 address of the gateway and the subnet size of the network in CIDR notation e.g.
+	// "192.168.10.1/24".
+	IpSubnet string `json:"ip_subnet,omitempty"`
+	// The domain name of the network e.g. "localdomain".
+	DomainName string `json:"domain_name,omitempty"`
+	// Indicates whether IGMP snooping is enabled on the network.
+	IgmpSnooping bool `json:"igmp_snooping,omitempty"`
+	// Indicates whether the network is isolated from the other networks.
+	NetworkIsolationEnabled bool `json:"network_isolation_enabled,omitempty"`
+	// Indicates whether the DHCP server of the gateway is enabled on the network.
+	DhcpdEnabled bool `json:"dhcpd_enabled,omitempty"`
+	// The first IPv4 address of the DHCP range e.g. "192.168.10.6".
+	DhcpdStart string `json:"dhcpd_start,omitempty"`
+	// The last IPv4 address of the DHCP range e.g. "192.168.10.254".
+	DhcpdStop string `json:"dhcpd_stop,omitempty"`
+	// The DHCP lease time in seconds.
+	DhcpdLeaseTime int `json:"dhcpd_leasetime,omitempty"`
+	// Indicates whether the DNS servers (DhcpdDns1 - DhcpdDns4) are handed out instead of the
+	// gateway.
+	DhcpdDnsEnabled bool `json:"dhcpd_dns_enabled,omitempty"`
+	// The first DNS server handed out by the DHCP server.
+	DhcpdDns1 string `json:"dhcpd_dns_1,omitempty"`
+	// The second DNS server handed out by the DHCP server.
+	DhcpdDns2 string `json:"dhcpd_dns_2,omitempty"`
+	// The third DNS server handed out by the DHCP server.
+	DhcpdDns3 string `json:"dhcpd_dns_3,omitempty"`
+	// The fourth DNS server handed out by the DHCP server.
+	DhcpdDns4 string `json:"dhcpd_dns_4,omitempty"`
+	// Indicates whether DhcpdGateway is handed out as gateway instead of the UniFi gateway.
+	DhcpdGatewayEnabled bool `json:"dhcpd_gateway_enabled,omitempty"`
+	// The gateway handed out by the DHCP server.
+	DhcpdGateway string `json:"dhcpd_gateway,omitempty"`
+	// Indicates whether the NTP servers (DhcpdNtp1 and DhcpdNtp2) are handed out.
+	DhcpdNtpEnabled bool `json:"dhcpd_ntp_enabled,omitempty"`
+	// The first NTP server handed out by the DHCP server.
+	DhcpdNtp1 string `json:"dhcpd_ntp_1,omitempty"`
+	// The second NTP server handed out by the DHCP server.
+	DhcpdNtp2 string `json:"dhcpd_ntp_2,omitempty"`
+	// Indicates whether network boot (DhcpdBootServer and DhcpdBootFilename) is enabled.
+	DhcpdBootEnabled bool `json:"dhcpd_boot_enabled,omitempty"`
+	// The TFTP server used for network boot.
+	DhcpdBootServer string `json:"dhcpd_boot_server,omitempty"`
+	// The file used for network boot.
+	DhcpdBootFilename string `json:"dhcpd_boot_filename,omitempty"`
+	// The TFTP server handed out by the DHCP server (option 66).
+	DhcpdTftpServer string `json:"dhcpd_tftp_server,omitempty"`
+	// The time offset in seconds handed out by the DHCP server (option 2).
+	DhcpdTimeOffset int `json:"dhcpd_time_offset,omitempty"`
+	// The WPAD URL handed out by the DHCP server (option 252).
+	DhcpdWpadUrl string `json:"dhcpd_wpad_url,omitempty"`
+	// Indicates whether DHCP requests are relayed to another DHCP server instead.
+	DhcpRelayEnabled bool `json:"dhcp_relay_enabled,omitempty"`
+	// Indicates whether DHCP guarding is enabled, only the trusted DHCP servers (DhcpdIp1 -
+	// DhcpdIp3) are allowed to respond to DHCP requests.
+	DhcpGuardEnabled bool `json:"dhcpguard_enabled,omitempty"`
+	// The first trusted DHCP server used by DHCP guarding.
+	DhcpdIp1 string `json:"dhcpd_ip_1,omitempty"`
+	// The second trusted DHCP server used by DHCP guarding.
+	DhcpdIp2 string `json:"dhcpd_ip_2,omitempty"`
+	// The third trusted DHCP server used by DHCP guarding.
+	DhcpdIp3 string `json:"dhcpd_ip_3,omitempty"`
+	// The IPv6 configuration of the network, options:
+	//	- none: IPv6 is disabled.
+	//	- static: The IPv6 subnet is configured using Ipv6Subnet.
+	//	- pd: The IPv6 subnet is delegated by the WAN (prefix delegation).
+	Ipv6InterfaceType string `json:"ipv6_interface_type,omitempty"`
+	// The IPv6 address of the gateway and the subnet size of the network in CIDR notation, used
+	// when Ipv6InterfaceType is `static`.
+	Ipv6Subnet string `json:"ipv6_subnet,omitempty"`
+	// The WAN network group (e.g. wan, wan2) delegating the prefix, used when Ipv6InterfaceType is
+	// `pd`.
+	Ipv6PdInterface string `json:"ipv6_pd_interface,omitempty"`
+	// The prefix ID (hexadecimal) of the delegated prefix, used when Ipv6InterfaceType is `pd`.
+	Ipv6PdPrefixid string `json:"ipv6_pd_prefixid,omitempty"`
+	// The first address of the DHCPv6 range of the delegated prefix e.g. "::2".
+	Ipv6PdStart string `json:"ipv6_pd_start,omitempty"`
+	// The last address of the DHCPv6 range of the delegated prefix e.g. "::7d1".
+	Ipv6PdStop string `json:"ipv6_pd_stop,omitempty"`
+	// Indicates whether router advertisements are sent on the network.
+	Ipv6RaEnabled bool `json:"ipv6_ra_enabled,omitempty"`
+	// The router advertisement priority, options: high, medium, low.
+	Ipv6RaPriority string `json:"ipv6_ra_priority,omitempty"`
+	// Indicates whether the DHCPv6 server of the gateway is enabled on the network.
+	Dhcpdv6Enabled bool `json:"dhcpdv6_enabled,omitempty"`
+	// The first address of the DHCPv6 range e.g. "::2".
+	Dhcpdv6Start string `json:"dhcpdv6_start,omitempty"`
+	// The last address of the DHCPv6 range e.g. "::7d1".
+	Dhcpdv6Stop string `json:"dhcpdv6_stop,omitempty"`
+	// The DHCPv6 lease time in seconds.
+	Dhcpdv6LeaseTime int `json:"dhcpdv6_leasetime,omitempty"`
+	// Indicates whether the DNS servers are handed out automatically by the DHCPv6 server.
+	Dhcpdv6DnsAuto bool `json:"dhcpdv6_dns_auto,omitempty"`
+	// The type of internet connection (purpose `wan`), options: dhcp, static, pppoe.
+	WanType string `json:"wan_type,omitempty"`
+	// The static IPv4 address of the internet connection, used when WanType is `static`.
+	WanIp string `json:"wan_ip,omitempty"`
+	// The netmask of the internet connection, used when WanType is `static`.
+	WanNetmask string `json:"wan_netmask,omitempty"`
+	// The gateway of the internet connection, used when WanType is `static`.
+	WanGateway string `json:"wan_gateway,omitempty"`
+	// The first DNS server of the internet connection.
+	WanDns1 string `json:"wan_dns1,omitempty"`
+	// The second DNS server of the internet connection.
+	WanDns2 string `json:"wan_dns2,omitempty"`
+	// The username of the internet connection, used when WanType is `pppoe`.
+	WanUsername string `json:"wan_username,omitempty"`
+	// The password of the internet connection, used when WanType is `pppoe`.
+	WanPassword string `json:"x_wan_password,omitempty"`
+}
+
+// CreateNetwork creates a new network linked to this [Site] using the given network data.
+// It will return an error if the creation of the network failed.
+func (site *Site) CreateNetwork(network Network) (NetworkResponse, error) {
+	return site.CreateNetworkWithContext(context.Background(), network)
+}
+
+// CreateNetworkWithContext is the same as [Site.CreateNetwork] but uses the given context for the
+// request.
+func (site *Site) CreateNetworkWithContext(
+	ctx context.Context,
+	network Network,
+) (NetworkResponse, error) {
+	return networkResource.create(ctx, site, network)
+}
+
+// GetAllNetworks returns all networks linked to this [Site].
+// It will return an error if it fails to fetch the networks.
+func (site *Site) GetAllNetworks() (NetworkResponse, error) {
+	return site.GetAllNetworksWithContext(context.Background())
+}
+
+// GetAllNetworksWithContext is the same as [Site.GetAllNetworks] but uses the given context for the
+// request.
+func (site *Site) GetAllNetworksWithContext(ctx context.Context) (NetworkResponse, error) {
+	return networkResource.getAll(ctx, site)
+}
+
+// GetNetwork returns the network linked to the given ID and this [Site].
+// It will return an error if it fails to fetch the specific network, however if no network with
+// the given ID is present or the ID is invalid no error but a response with an empty data array
+// will be returned.
+func (site *Site) GetNetwork(id string) (NetworkResponse, error) {
+	return site.GetNetworkWithContext(context.Background(), id)
+}
+
+// GetNetworkWithContext is the same as [Site.GetNetwork] but uses the given context for the
+// request.
+func (site *Site) GetNetworkWithContext(ctx context.Context, id string) (NetworkResponse, error) {
+	return networkResource.get(ctx, site, id)
+}
+
+// UpdateNetwork updates the network linked to the given ID and this [Site] using the given network
+// data. It will return an error if the update of the network failed.
+func (site *Site) UpdateNetwork(id string, network Network) (NetworkResponse, error) {
+	return site.UpdateNetworkWithContext(context.Background(), id, network)
+}
+
+// UpdateNetworkWithContext is the same as [Site.UpdateNetwork] but uses the given context for the
+// request.
+func (site *Site) UpdateNetworkWithContext(
+	ctx context.Context,
+	id string,
+	network Network,
+) (NetworkResponse, error) {
+	return networkResource.update(ctx, site, id, network)
+}
+
+// DeleteNetwork deletes the network linked to the given ID and this [Site].
+// It will return an error if the deletion of the network failed.
+func (site *Site) DeleteNetwork(id string) (NetworkResponse, error) {
+	return site.DeleteNetworkWithContext(context.Background(), id)
+}
+
+// DeleteNetworkWithContext is the same as [Site.DeleteNetwork] but uses the given context for the
+// request.
+func (site *Site) DeleteNetworkWithContext(
+	ctx context.Context,
+	id string,
+) (NetworkResponse, error) {
+	return networkResource.delete(ctx, site, id)
+}
@@ -0,0 +1,182 @@
+package unifi
+
+import "context"
+
+// PortForwardResponse is the representation of a response of a port forward request.
+type PortForwardResponse struct {
+	Meta Meta                      `json:"meta"`
+	Data []PortForwardResponseData `json:"data"`
+}
+
+// PortForwardResponseData is the representation of the data inside the data array of the
+// [PortForwardResponse]. It contains either [PortForward] or [DataValidationError] based on
+// whether the request succeeded.
+type PortForwardResponseData struct {
+	*PortForward
+	*DataValidationError
+}
+
+// The `rest/portforward` endpoint managing the port forwards of a [Site].
+var portForwardResource = restResource[PortForward, PortForwardResponse]{
+	path:       "rest/portforward",
+	name:       "port forward",
+	pluralName: "port forwards",
+}
+
+// PortForward is the representation of a port forwarding rule.
+type PortForward struct {
+	// The port forward ID.
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this port forward.
+	SiteId string `json:"site_id,omitempty"`
+	// The name of the port forward.
+	Name string `json:"name,omitempty"`
+	// Indicates whether the port forward is active, use [Site.EnablePortForward] and
+	// [Site.DisablePortForward] to change it as false is omitted.
+	Enabled bool `json:"enabled,omitempty"`
+	// The source addresses allowed to use the port forward, options:
+	//	- any: Any source address is allowed.
+	//	- An IPv4 address or subnet in CIDR notation e.g. "203.0.113.0/24".
+	Src string `json:"src,omitempty"`
+	// The WAN interface on which the port forward is applied, options:
+	//	- wan: The primary internet connection.
+	//	- wan2: The secondary internet connection.
+	//	- both: Both internet connections.
+	PfwdInterface string `json:"pfwd_interface,omitempty"`
+	// The external port(s) and/or port range(s) e.g. "80", "8000-9000".
+	DstPort string `json:"dst_port,omitempty"`
+	// The IPv4 address of the machine to which the traffic is forwarded.
+	Fwd string `json:"fwd,omitempty"`
+	// The port(s) and/or port range(s) to which the traffic is forwarded e.g. "80", "8000-9000".
+	FwdPort string `json:"fwd_port,omitempty"`
+	// The protocol of the forwarded traffic, options: tcp_udp, tcp, udp.
+	Proto string `json:"proto,omitempty"`
+	// Generates a syslog entry when this port forward is matched, use
+	// [Site.SetPortForwardLogging] to change it as false is omitted.
+	Log bool `json:"log,omitempty"`
+}
+
+// CreatePortForward creates a new port forward linked to this [Site] using the given port forward
+// data. It will return an error if the creation of the port forward failed.
+func (site *Site) CreatePortForward(portForward PortForward) (PortForwardResponse, error) {
+	return site.CreatePortForwardWithContext(context.Background(), portForward)
+}
+
+// CreatePortForwardWithContext is the same as [Site.CreatePortForward] but uses the given context
+// for the request.
+func (site *Site) CreatePortForwardWithContext(
+	ctx context.Context,
+	portForward PortForward,
+) (PortForwardResponse, error) {
+	return portForwardResource.create(ctx, site, portForward)
+}
+
+// GetAllPortForwards returns all port forwards linked to this [Site].
+// It will return an error if it fails to fetch the port forwards.
+func (site *Site) GetAllPortForwards() (PortForwardResponse, error) {
+	return site.GetAllPortForwardsWithContext(context.Background())
+}
+
+// GetAllPortForwardsWithContext is the same as [Site.GetAllPortForwards] but uses the given
+// context for the request.
+func (site *Site) GetAllPortForwardsWithContext(ctx context.Context) (PortForwardResponse, error) {
+	return portForwardResource.getAll(ctx, site)
+}
+
+// GetPortForward returns the port forward linked to the given ID and this [Site].
+// It will return an error if it fails to fetch the specific port forward, however if no port
+// forward with the given ID is present or the ID is invalid no error but a response with an empty
+// data array will be returned.
+func (site *Site) GetPortForward(id string) (PortForwardResponse, error) {
+	return site.GetPortForwardWithContext(context.Background(), id)
+}
+
+// GetPortForwardWithContext is the same as [Site.GetPortForward] but uses the given context for
+// the request.
+func (site *Site) GetPortForwardWithContext(
+	ctx context.Context,
+	id string,
+) (PortForwardResponse, error) {
+	return portForwardResource.get(ctx, site, id)
+}
+
+// UpdatePortForward updates the port forward linked to the given ID and this [Site] using the
+// given port forward data. It will return an error if the update of the port forward failed.
+func (site *Site) UpdatePortForward(
+	id string,
+	portForward PortForward,
+) (PortForwardResponse, error) {
+	return site.UpdatePortForwardWithContext(context.Background(), id, portForward)
+}
+
+// UpdatePortForwardWithContext is the same as [Site.UpdatePortForward] but uses the given context
+// for the request.
+func (site *Site) UpdatePortForwardWithContext(
+	ctx context.Context,
+	id string,
+	portForward PortForward,
+) (PortForwardResponse, error) {
+	return portForwardResource.update(ctx, site, id, portForward)
+}
+
+// EnablePortForward enables the port forward linked to the given ID and this [Site].
+// It will return an error if the update of the port forward failed.
+func (site *Site) EnablePortForward(id string) (PortForwardResponse, error) {
+	return site.EnablePortForwardWithContext(context.Background(), id)
+}
+
+// EnablePortForwardWithContext is the same as [Site.EnablePortForward] but uses the given context
+// for the request.
+func (site *Site) EnablePortForwardWithContext(
+	ctx context.Context,
+	id string,
+) (PortForwardResponse, error) {
+	return portForwardResource.updateFields(ctx, site, id, map[string]bool{"enabled": true})
+}
+
+// DisablePortForward disables the port forward linked to the given ID and this [Site].
+// It will return an error if the update of the port forward failed.
+func (site *Site) DisablePortForward(id string) (PortForwardResponse, error) {
+	return site.DisablePortForwardWithContext(context.Background(), id)
+}
+
+// DisablePortForwardWithContext is the same as [Site.DisablePortForward] but uses the given
+// context for the request.
+func (site *Site) DisablePortForwardWithContext(
+	ctx context.Context,
+	id string,
+) (PortForwardResponse, error) {
+	return portForwardResource.updateFields(ctx, site, id, map[string]bool{"enabled": false})
+}
+
+// SetPortForwardLogging updates whether a syslog entry is generated when the port forward linked
+// to the given ID and this [Site] is matched.
+// It will return an error if the update of the port forward failed.
+func (site *Site) SetPortForwardLogging(id string, log bool) (PortForwardResponse, error) {
+	return site.SetPortForwardLoggingWithContext(context.Background(), id, log)
+}
+
+// SetPortForwardLoggingWithContext is the same as [Site.SetPortForwardLogging] but uses the given
+// context for the request.
+func (site *Site) SetPortForwardLoggingWithContext(
+	ctx context.Context,
+	id string,
+	log bool,
+) (PortForwardResponse, error) {
+	return portForwardResource.updateFields(ctx, site, id, map[string]bool{"log": log})
+}
+
+// DeletePortForward deletes the port forward linked to the given ID and this [Site].
+// It will return an error if the deletion of the port forward failed.
+func (site *Site) DeletePortForward(id string) (PortForwardResponse, error) {
+	return site.DeletePortForwardWithContext(context.Background(), id)
+}
+
+// DeletePortForwardWithContext is the same as [Site.DeletePortForward] but uses the given context
+// for the request.
+func (site *Site) DeletePortForwardWithContext(
+	ctx context.Context,
+	id string,
+) (PortForwardResponse, error) {
+	return portForwardResource.delete(ctx, site, id)
+}
@@ -0,0 +1,68 @@
+package unifi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestPortForwardFieldUpdatesSendFalse(t *testing.T) {
+	tests := []struct {
+		name     string
+		update   func(site *Site) (PortForwardResponse, error)
+		expected map[string]bool
+	}{
+		{
+			name: "disable",
+			update: func(site *Site) (PortForwardResponse, error) {
+				return site.DisablePortForward("1")
+			},
+			expected: map[string]bool{"enabled": false},
+		},
+		{
+			name: "enable",
+			update: func(site *Site) (PortForwardResponse, error) {
+				return site.EnablePortForward("1")
+			},
+			expected: map[string]bool{"enabled": true},
+		},
+		{
+			name: "disable logging",
+			update: func(site *Site) (PortForwardResponse, error) {
+				return site.SetPortForwardLogging("1", false)
+			},
+			expected: map[string]bool{"log": false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+			var body map[string]bool
+			server.handler = func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut || r.URL.Path != "/api/s/default/rest/portforward/1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				byteArray, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(byteArray, &body); err != nil {
+					t.Errorf("parsing request body %q failed: %s", byteArray, err)
+				}
+				_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+			}
+			site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+			_, err := test.update(site)
+			if err != nil {
+				t.Fatalf("updating port forward failed: %s", err)
+			}
+			if len(body) != len(test.expected) {
+				t.Fatalf("expected body %v, got %v", test.expected, body)
+			}
+			for field, value := range test.expected {
+				if actual, ok := body[field]; !ok || actual != value {
+					t.Errorf("expected body %v, got %v", test.expected, body)
+				}
+			}
+		})
+	}
+}
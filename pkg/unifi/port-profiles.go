@@ -0,0 +1,184 @@
+package unifi
+
+import "context"
+
+// PortProfileResponse is the representation of a response of a port profile request.
+type PortProfileResponse struct {
+	Meta Meta                      `json:"meta"`
+	Data []PortProfileResponseData `json:"data"`
+}
+
+// PortProfileResponseData is the representation of the data inside the data array of the
+// [PortProfileResponse]. It contains either [PortProfile] or [DataValidationError] based on
+// whether the request succeeded.
+type PortProfileResponseData struct {
+	*PortProfile
+	*DataValidationError
+}
+
+// The `rest/portconf` endpoint managing the port profiles of a [Site].
+var portProfileResource = restResource[PortProfile, PortProfileResponse]{
+	path:       "rest/portconf",
+	name:       "port profile",
+	pluralName: "port profiles",
+}
+
+// PortProfile is the representation of a (switch) port profile, a profile can be applied to the
+// ports of multiple devices (see [PortOverride]).
+type PortProfile struct {
+	// The port profile ID.
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this port profile.
+	SiteId string `json:"site_id,omitempty"`
+	// The name of the port profile.
+	Name string `json:"name,omitempty"`
+	PortSettings
+}
+
+// PortSettings is the representation of the settings of a switch port, used by both a
+// [PortProfile] and a [PortOverride]. The boolean settings are pointers so they can be set to
+// false, a nil setting is not included in the request.
+type PortSettings struct {
+	// The operation mode of the port, options:
+	//	- switch: The port switches traffic (default).
+	//	- mirror: The port mirrors the traffic of another port.
+	//	- aggregate: The port is part of a link aggregation.
+	OpMode string `json:"op_mode,omitempty"`
+	// The ID of the native (untagged) network (see [Network]) of the port.
+	NativeNetworkConfId string `json:"native_networkconf_id,omitempty"`
+	// The ID of the voice network (see [Network]) of the port.
+	VoiceNetworkConfId string `json:"voice_networkconf_id,omitempty"`
+	// Determines which networks are forwarded by the port (older controllers), options:
+	//	- all: All networks.
+	//	- native: Only the native network.
+	//	- customize: The native network and the networks in TaggedNetworkConfIds.
+	//	- disabled: The port is disabled.
+	Forward string `json:"forward,omitempty"`
+	// The IDs of the tagged networks (see [Network]) of the port, used when Forward is
+	// `customize`.
+	TaggedNetworkConfIds []string `json:"tagged_networkconf_ids,omitempty"`
+	// Determines which networks are tagged on the port, options:
+	//	- auto: All networks.
+	//	- block_all: No networks.
+	//	- custom: All networks except the networks in ExcludedNetworkConfIds.
+	TaggedVlanMgmt string `json:"tagged_vlan_mgmt,omitempty"`
+	// The IDs of the networks (see [Network]) which are not tagged on the port.
+	ExcludedNetworkConfIds []string `json:"excluded_networkconf_ids,omitempty"`
+	// The PoE mode of the port, options: auto, pasv24, passthrough, off.
+	PoeMode string `json:"poe_mode,omitempty"`
+	// Indicates whether the speed and duplex of the port are negotiated automatically.
+	Autoneg *bool `json:"autoneg,omitempty"`
+	// The speed of the port in Mbps, used when Autoneg is false.
+	Speed int `json:"speed,omitempty"`
+	// Indicates whether the port is full duplex, used when Autoneg is false.
+	FullDuplex *bool `json:"full_duplex,omitempty"`
+	// Indicates whether the port is isolated from the other isolated ports.
+	Isolation *bool `json:"isolation,omitempty"`
+	// Indicates whether the spanning tree protocol is enabled on the port.
+	StpPortMode *bool `json:"stp_port_mode,omitempty"`
+	// Indicates whether LLDP-MED is enabled on the port.
+	LldpmedEnabled *bool `json:"lldpmed_enabled,omitempty"`
+	// Indicates whether broadcast storm control is enabled on the port.
+	StormctrlBcastEnabled *bool `json:"stormctrl_bcast_enabled,omitempty"`
+	// The maximum broadcast rate in percent of the port speed.
+	StormctrlBcastRate int `json:"stormctrl_bcast_rate,omitempty"`
+	// Indicates whether multicast storm control is enabled on the port.
+	StormctrlMcastEnabled *bool `json:"stormctrl_mcast_enabled,omitempty"`
+	// The maximum multicast rate in percent of the port speed.
+	StormctrlMcastRate int `json:"stormctrl_mcast_rate,omitempty"`
+	// Indicates whether unknown unicast storm control is enabled on the port.
+	StormctrlUcastEnabled *bool `json:"stormctrl_ucast_enabled,omitempty"`
+	// The maximum unknown unicast rate in percent of the port speed.
+	StormctrlUcastRate int `json:"stormctrl_ucast_rate,omitempty"`
+	// The 802.1X control of the port, options:
+	//	- force_authorized: All clients are allowed (802.1X disabled).
+	//	- force_unauthorized: No clients are allowed.
+	//	- auto: Clients have to authenticate using 802.1X.
+	//	- mac_based: Clients are authenticated using their MAC address.
+	//	- multi_host: All clients are allowed once a client has authenticated.
+	Dot1xCtrl string `json:"dot1x_ctrl,omitempty"`
+	// The number of seconds after which an idle 802.1X authenticated client is removed.
+	Dot1xIdleTimeout int `json:"dot1x_idle_timeout,omitempty"`
+	// Indicates whether only the MAC addresses in PortSecurityMacAddress are allowed on the port.
+	PortSecurityEnabled *bool `json:"port_security_enabled,omitempty"`
+	// The MAC addresses allowed on the port, used when PortSecurityEnabled is true.
+	PortSecurityMacAddress []string `json:"port_security_mac_address,omitempty"`
+}
+
+// CreatePortProfile creates a new port profile linked to this [Site] using the given port profile
+// data. It will return an error if the creation of the port profile failed.
+func (site *Site) CreatePortProfile(portProfile PortProfile) (PortProfileResponse, error) {
+	return site.CreatePortProfileWithContext(context.Background(), portProfile)
+}
+
+// CreatePortProfileWithContext is the same as [Site.CreatePortProfile] but uses the given context
+// for the request.
+func (site *Site) CreatePortProfileWithContext(
+	ctx context.Context,
+	portProfile PortProfile,
+) (PortProfileResponse, error) {
+	return portProfileResource.create(ctx, site, portProfile)
+}
+
+// GetAllPortProfiles returns all port profiles linked to this [Site].
+// It will return an error if it fails to fetch the port profiles.
+func (site *Site) GetAllPortProfiles() (PortProfileResponse, error) {
+	return site.GetAllPortProfilesWithContext(context.Background())
+}
+
+// GetAllPortProfilesWithContext is the same as [Site.GetAllPortProfiles] but uses the given
+// context for the request.
+func (site *Site) GetAllPortProfilesWithContext(ctx context.Context) (PortProfileResponse, error) {
+	return portProfileResource.getAll(ctx, site)
+}
+
+// GetPortProfile returns the port profile linked to the given ID and this [Site].
+// It will return an error if it fails to fetch the specific port profile, however if no port
+// profile with the given ID is present or the ID is invalid no error but a response with an empty
+// data array will be returned.
+func (site *Site) GetPortProfile(id string) (PortProfileResponse, error) {
+	return site.GetPortProfileWithContext(context.Background(), id)
+}
+
+// GetPortProfileWithContext is the same as [Site.GetPortProfile] but uses the given context for
+// the request.
+func (site *Site) GetPortProfileWithContext(
+	ctx context.Context,
+	id string,
+) (PortProfileResponse, error) {
+	return portProfileResource.get(ctx, site, id)
+}
+
+// UpdatePortProfile updates the port profile linked to the given ID and this [Site] using the
+// given port profile data. It will return an error if the update of the port profile failed.
+func (site *Site) UpdatePortProfile(
+	id string,
+	portProfile PortProfile,
+) (PortProfileResponse, error) {
+	return site.UpdatePortProfileWithContext(context.Background(), id, portProfile)
+}
+
+// UpdatePortProfileWithContext is the same as [Site.UpdatePortProfile] but uses the given context
+// for the request.
+func (site *Site) UpdatePortProfileWithContext(
+	ctx context.Context,
+	id string,
+	portProfile PortProfile,
+) (PortProfileResponse, error) {
+	return portProfileResource.update(ctx, site, id, portProfile)
+}
+
+// DeletePortProfile deletes the port profile linked to the given ID and this [Site].
+// It will return an error if the deletion of the port profile failed.
+func (site *Site) DeletePortProfile(id string) (PortProfileResponse, error) {
+	return site.DeletePortProfileWithContext(context.Background(), id)
+}
+
+// DeletePortProfileWithContext is the same as [Site.DeletePortProfile] but uses the given context
+// for the request.
+func (site *Site) DeletePortProfileWithContext(
+	ctx context.Context,
+	id string,
+) (PortProfileResponse, error) {
+	return portProfileResource.delete(ctx, site, id)
+}
@@ -0,0 +1,41 @@
+package unifi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestUpdatePortProfileSendsFalseSettings(t *testing.T) {
+	server := newTestServer(t)
+	var body map[string]any
+	server.handler = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/s/default/rest/portconf/profile-id" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		byteArray, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(byteArray, &body); err != nil {
+			t.Errorf("parsing request body %q failed: %s", byteArray, err)
+		}
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	disabled := false
+	_, err := site.UpdatePortProfile("profile-id", PortProfile{
+		Name:         "Servers",
+		PortSettings: PortSettings{Autoneg: &disabled, StpPortMode: &disabled, Speed: 1000},
+	})
+	if err != nil {
+		t.Fatalf("updating port profile failed: %s", err)
+	}
+	for _, field := range []string{"autoneg", "stp_port_mode"} {
+		if value, ok := body[field]; !ok || value != false {
+			t.Errorf("expected %s to be sent as false, got %v", field, value)
+		}
+	}
+	if _, ok := body["isolation"]; ok {
+		t.Errorf("expected unset isolation to be omitted, got %v", body["isolation"])
+	}
+}
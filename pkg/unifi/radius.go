@@ -0,0 +1,280 @@
+package unifi
+
+import "context"
+
+// RadiusProfileResponse is the representation of a response of a RADIUS profile request.
+type RadiusProfileResponse struct {
+	Meta Meta                        `json:"meta"`
+	Data []RadiusProfileResponseData `json:"data"`
+}
+
+// RadiusProfileResponseData is the representation of the data inside the data array of the
+// [RadiusProfileResponse]. It contains either [RadiusProfile] or [DataValidationError] based on
+// whether the request succeeded.
+type RadiusProfileResponseData struct {
+	*RadiusProfile
+	*DataValidationError
+}
+
+// RadiusAccountResponse is the representation of a response of a RADIUS account request.
+type RadiusAccountResponse struct {
+	Meta Meta                        `json:"meta"`
+	Data []RadiusAccountResponseData `json:"data"`
+}
+
+// RadiusAccountResponseData is the representation of the data inside the data array of the
+// [RadiusAccountResponse]. It contains either [RadiusAccount] or [DataValidationError] based on
+// whether the request succeeded.
+type RadiusAccountResponseData struct {
+	*RadiusAccount
+	*DataValidationError
+}
+
+// The `rest/radiusprofile` endpoint managing the RADIUS profiles of a [Site].
+var radiusProfileResource = restResource[RadiusProfile, RadiusProfileResponse]{
+	path:       "rest/radiusprofile",
+	name:       "RADIUS profile",
+	pluralName: "RADIUS profiles",
+}
+
+// The `rest/account` endpoint managing the accounts of the built-in RADIUS server of a [Site].
+var radiusAccountResource = restResource[RadiusAccount, RadiusAccountResponse]{
+	path:       "rest/account",
+	name:       "RADIUS account",
+	pluralName: "RADIUS accounts",
+}
+
+// RadiusProfile is the representation of a RADIUS profile, which determines the RADIUS servers
+// used for authentication (e.g. by a [Wlan] using WPA enterprise or by 802.1X port control) and
+// accounting. The boolean settings (except AttrNoDelete) are pointers so an update can disable
+// them, nil settings are omitted.
+type RadiusProfile struct {
+	// The RADIUS profile ID.
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this RADIUS profile.
+	SiteId string `json:"site_id,omitempty"`
+	// The name of the RADIUS profile.
+	Name string `json:"name,omitempty"`
+	// Indicates whether the built-in RADIUS server of the gateway is used for authentication
+	// instead of the AuthServers.
+	UseUsgAuthServer *bool `json:"use_usg_auth_server,omitempty"`
+	// The RADIUS servers used for authentication.
+	AuthServers []RadiusServer `json:"auth_servers,omitempty"`
+	// Indicates whether RADIUS accounting is enabled.
+	AccountingEnabled *bool `json:"accounting_enabled,omitempty"`
+	// Indicates whether the built-in RADIUS server of the gateway is used for accounting instead
+	// of the AcctServers.
+	UseUsgAcctServer *bool `json:"use_usg_acct_server,omitempty"`
+	// The RADIUS servers used for accounting, used when AccountingEnabled is true.
+	AcctServers []RadiusServer `json:"acct_servers,omitempty"`
+	// Indicates whether interim accounting updates are sent.
+	InterimUpdateEnabled *bool `json:"interim_update_enabled,omitempty"`
+	// The interval of the interim accounting updates in seconds.
+	InterimUpdateInterval int `json:"interim_update_interval,omitempty"`
+	// Indicates whether the RADIUS server can assign a VLAN to a wired client (see
+	// [RadiusAccount.Vlan]).
+	VlanEnabled *bool `json:"vlan_enabled,omitempty"`
+	// Determines whether the RADIUS server assigns a VLAN to a wireless client, options:
+	//	- disabled: No VLAN is assigned.
+	//	- optional: A VLAN is assigned if the RADIUS server returns one.
+	//	- required: A client is rejected if the RADIUS server does not return a VLAN.
+	VlanWlanMode string `json:"vlan_wlan_mode,omitempty"`
+	// Indicates whether the RADIUS profile can not be deleted (e.g. the default RADIUS profile).
+	AttrNoDelete bool `json:"attr_no_delete,omitempty"`
+	// The ID of the hidden attributes of the RADIUS profile e.g. "Default" for the default RADIUS
+	// profile.
+	AttrHiddenId string `json:"attr_hidden_id,omitempty"`
+}
+
+// RadiusServer is the representation of a RADIUS server of a [RadiusProfile].
+type RadiusServer struct {
+	// The IP address of the RADIUS server.
+	Ip string `json:"ip,omitempty"`
+	// The port of the RADIUS server e.g. 1812 for authentication or 1813 for accounting.
+	Port int `json:"port,omitempty"`
+	// The shared secret of the RADIUS server.
+	Secret string `json:"x_secret,omitempty"`
+}
+
+// RadiusAccount is the representation of an account of the built-in RADIUS server.
+type RadiusAccount struct {
+	// The RADIUS account ID.
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this RADIUS account.
+	SiteId string `json:"site_id,omitempty"`
+	// The username of the account.
+	Name string `json:"name,omitempty"`
+	// The password of the account.
+	Password string `json:"x_password,omitempty"`
+	// The RADIUS tunnel type (RFC 2868) returned for the account e.g. 13 for VLAN.
+	TunnelType int `json:"tunnel_type,omitempty"`
+	// The RADIUS tunnel medium type (RFC 2868) returned for the account e.g. 6 for 802 (includes
+	// all 802 media plus Ethernet canonical format).
+	TunnelMediumType int `json:"tunnel_medium_type,omitempty"`
+	// The VLAN ID assigned to the clients using the account, used when TunnelType is 13.
+	Vlan int `json:"vlan,omitempty"`
+	// The ID of the network (see [Network]) assigned to the clients using the account (newer
+	// controllers use this instead of Vlan).
+	NetworkConfId string `json:"networkconf_id,omitempty"`
+}
+
+// CreateRadiusProfile creates a new RADIUS profile linked to this [Site] using the given RADIUS
+// profile data. It will return an error if the creation of the RADIUS profile failed.
+func (site *Site) CreateRadiusProfile(radiusProfile RadiusProfile) (RadiusProfileResponse, error) {
+	return site.CreateRadiusProfileWithContext(context.Background(), radiusProfile)
+}
+
+// CreateRadiusProfileWithContext is the same as [Site.CreateRadiusProfile] but uses the given
+// context for the request.
+func (site *Site) CreateRadiusProfileWithContext(
+	ctx context.Context,
+	radiusProfile RadiusProfile,
+) (RadiusProfileResponse, error) {
+	return radiusProfileResource.create(ctx, site, radiusProfile)
+}
+
+// GetAllRadiusProfiles returns all RADIUS profiles linked to this [Site].
+// It will return an error if it fails to fetch the RADIUS profiles.
+func (site *Site) GetAllRadiusProfiles() (RadiusProfileResponse, error) {
+	return site.GetAllRadiusProfilesWithContext(context.Background())
+}
+
+// GetAllRadiusProfilesWithContext is the same as [Site.GetAllRadiusProfiles] but uses the given
+// context for the request.
+func (site *Site) GetAllRadiusProfilesWithContext(
+	ctx context.Context,
+) (RadiusProfileResponse, error) {
+	return radiusProfileResource.getAll(ctx, site)
+}
+
+// GetRadiusProfile returns the RADIUS profile linked to the given ID and this [Site].
+// It will return an error if it fails to fetch the specific RADIUS profile, however if no RADIUS
+// profile with the given ID is present or the ID is invalid no error but a response with an empty
+// data array will be returned.
+func (site *Site) GetRadiusProfile(id string) (RadiusProfileResponse, error) {
+	return site.GetRadiusProfileWithContext(context.Background(), id)
+}
+
+// GetRadiusProfileWithContext is the same as [Site.GetRadiusProfile] but uses the given context
+// for the request.
+func (site *Site) GetRadiusProfileWithContext(
+	ctx context.Context,
+	id string,
+) (RadiusProfileResponse, error) {
+	return radiusProfileResource.get(ctx, site, id)
+}
+
+// UpdateRadiusProfile updates the RADIUS profile linked to the given ID and this [Site] using the
+// given RADIUS profile data. It will return an error if the update of the RADIUS profile failed.
+func (site *Site) UpdateRadiusProfile(
+	id string,
+	radiusProfile RadiusProfile,
+) (RadiusProfileResponse, error) {
+	return site.UpdateRadiusProfileWithContext(context.Background(), id, radiusProfile)
+}
+
+// UpdateRadiusProfileWithContext is the same as [Site.UpdateRadiusProfile] but uses the given
+// context for the request.
+func (site *Site) UpdateRadiusProfileWithContext(
+	ctx context.Context,
+	id string,
+	radiusProfile RadiusProfile,
+) (RadiusProfileResponse, error) {
+	return radiusProfileResource.update(ctx, site, id, radiusProfile)
+}
+
+// DeleteRadiusProfile deletes the RADIUS profile linked to the given ID and this [Site].
+// It will return an error if the deletion of the RADIUS profile failed.
+func (site *Site) DeleteRadiusProfile(id string) (RadiusProfileResponse, error) {
+	return site.DeleteRadiusProfileWithContext(context.Background(), id)
+}
+
+// DeleteRadiusProfileWithContext is the same as [Site.DeleteRadiusProfile] but uses the given
+// context for the request.
+func (site *Site) DeleteRadiusProfileWithContext(
+	ctx context.Context,
+	id string,
+) (RadiusProfileResponse, error) {
+	return radiusProfileResource.delete(ctx, site, id)
+}
+
+// CreateRadiusAccount creates a new account of the built-in RADIUS server linked to this [Site]
+// using the given RADIUS account data.
+// It will return an error if the creation of the RADIUS account failed.
+func (site *Site) CreateRadiusAccount(radiusAccount RadiusAccount) (RadiusAccountResponse, error) {
+	return site.CreateRadiusAccountWithContext(context.Background(), radiusAccount)
+}
+
+// CreateRadiusAccountWithContext is the same as [Site.CreateRadiusAccount] but uses the given
+// context for the request.
+func (site *Site) CreateRadiusAccountWithContext(
+	ctx context.Context,
+	radiusAccount RadiusAccount,
+) (RadiusAccountResponse, error) {
+	return radiusAccountResource.create(ctx, site, radiusAccount)
+}
+
+// GetAllRadiusAccounts returns all accounts of the built-in RADIUS server linked to this [Site].
+// It will return an error if it fails to fetch the RADIUS accounts.
+func (site *Site) GetAllRadiusAccounts() (RadiusAccountResponse, error) {
+	return site.GetAllRadiusAccountsWithContext(context.Background())
+}
+
+// GetAllRadiusAccountsWithContext is the same as [Site.GetAllRadiusAccounts] but uses the given
+// context for the request.
+func (site *Site) GetAllRadiusAccountsWithContext(
+	ctx context.Context,
+) (RadiusAccountResponse, error) {
+	return radiusAccountResource.getAll(ctx, site)
+}
+
+// GetRadiusAccount returns the RADIUS account linked to the given ID and this [Site].
+// It will return an error if it fails to fetch the specific RADIUS account, however if no RADIUS
+// account with the given ID is present or the ID is invalid no error but a response with an empty
+// data array will be returned.
+func (site *Site) GetRadiusAccount(id string) (RadiusAccountResponse, error) {
+	return site.GetRadiusAccountWithContext(context.Background(), id)
+}
+
+// GetRadiusAccountWithContext is the same as [Site.GetRadiusAccount] but uses the given context
+// for the request.
+func (site *Site) GetRadiusAccountWithContext(
+	ctx context.Context,
+	id string,
+) (RadiusAccountResponse, error) {
+	return radiusAccountResource.get(ctx, site, id)
+}
+
+// UpdateRadiusAccount updates the RADIUS account linked to the given ID and this [Site] using the
+// given RADIUS account data. It will return an error if the update of the RADIUS account failed.
+func (site *Site) UpdateRadiusAccount(
+	id string,
+	radiusAccount RadiusAccount,
+) (RadiusAccountResponse, error) {
+	return site.UpdateRadiusAccountWithContext(context.Background(), id, radiusAccount)
+}
+
+// UpdateRadiusAccountWithContext is the same as [Site.UpdateRadiusAccount] but uses the given
+// context for the request.
+func (site *Site) UpdateRadiusAccountWithContext(
+	ctx context.Context,
+	id string,
+	radiusAccount RadiusAccount,
+) (RadiusAccountResponse, error) {
+	return radiusAccountResource.update(ctx, site, id, radiusAccount)
+}
+
+// DeleteRadiusAccount deletes the RADIUS account linked to the given ID and this [Site].
+// It will return an error if the deletion of the RADIUS account failed.
+func (site *Site) DeleteRadiusAccount(id string) (RadiusAccountResponse, error) {
+	return site.DeleteRadiusAccountWithContext(context.Background(), id)
+}
+
+// DeleteRadiusAccountWithContext is the same as [Site.DeleteRadiusAccount] but uses the given
+// context for the request.
+func (site *Site) DeleteRadiusAccountWithContext(
+	ctx context.Context,
+	id string,
+) (RadiusAccountResponse, error) {
+	return radiusAccountResource.delete(ctx, site, id)
+}
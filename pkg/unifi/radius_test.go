@@ -0,0 +1,52 @@
+package unifi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestUpdateRadiusProfileSendsFalseSettings(t *testing.T) {
+	server := newTestServer(t)
+	var body map[string]any
+	server.handler = func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut ||
+			r.URL.Path != "/api/s/default/rest/radiusprofile/profile-id" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		byteArray, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(byteArray, &body); err != nil {
+			t.Errorf("parsing request body %q failed: %s", byteArray, err)
+		}
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	enabled, disabled := true, false
+	_, err := site.UpdateRadiusProfile("profile-id", RadiusProfile{
+		Name:                 "Enterprise",
+		UseUsgAuthServer:     &enabled,
+		AccountingEnabled:    &disabled,
+		InterimUpdateEnabled: &disabled,
+		VlanEnabled:          &disabled,
+	})
+	if err != nil {
+		t.Fatalf("updating RADIUS profile failed: %s", err)
+	}
+	expected := map[string]bool{
+		"use_usg_auth_server":    true,
+		"accounting_enabled":     false,
+		"interim_update_enabled": false,
+		"vlan_enabled":           false,
+	}
+	for field, expectedValue := range expected {
+		if value, ok := body[field]; !ok || value != expectedValue {
+			t.Errorf("expected %s to be sent as %t, got %v", field, expectedValue, value)
+		}
+	}
+	if _, ok := body["use_usg_acct_server"]; ok {
+		t.Errorf("expected unset use_usg_acct_server to be omitted, got %v",
+			body["use_usg_acct_server"])
+	}
+}
@@ -0,0 +1,158 @@
+package unifi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Generic response errors, a [ResponseError] matches these errors (using [errors.Is]) based on the
+// status code and meta information of the response.
+var (
+	NotFoundError           = errors.New("object not found")
+	DuplicateNameError      = errors.New("duplicate name")
+	DuplicateRuleIndexError = errors.New("duplicate rule index")
+	InvalidObjectError      = errors.New("invalid object")
+)
+
+// A ResponseError is returned when the UniFi controller responds with a non 2xx response code.
+// It contains the details the UniFi controller included in the response, use [errors.As] to
+// retrieve it from a returned error.
+type ResponseError struct {
+	// The http response code.
+	StatusCode int
+	// The meta information included in the response (empty if not included).
+	Meta Meta
+	// The validation errors included in the data array of the response.
+	ValidationErrors []DataValidationError
+}
+
+// errorResponse is the representation of a response of a failed request.
+type errorResponse struct {
+	Meta Meta                  `json:"meta"`
+	Data []DataValidationError `json:"data"`
+}
+
+// Creates a [ResponseError] using the given response code and response body. The body is parsed
+// on a best effort basis, fields that can not be parsed are left empty.
+func newResponseError(statusCode int, body []byte) *ResponseError {
+	responseError := &ResponseError{StatusCode: statusCode}
+
+	var response errorResponse
+	if json.Unmarshal(body, &response) != nil {
+		// The data array could have an unexpected format, try to parse only the meta information.
+		response = errorResponse{}
+		_ = json.Unmarshal(body, &struct {
+			Meta *Meta `json:"meta"`
+		}{Meta: &response.Meta})
+	}
+	responseError.Meta = response.Meta
+
+	// Some APIs (e.g. the integration API and the UniFi OS login) do not include meta information
+	// but an error code and/or message instead.
+	if responseError.Meta.Msg == "" {
+		var messageResponse struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &messageResponse) == nil {
+			if messageResponse.Code != "" {
+				responseError.Meta.Msg = messageResponse.Code
+			} else {
+				responseError.Meta.Msg = messageResponse.Message
+			}
+		}
+	}
+
+	for _, data := range response.Data {
+		if data.ValidationError.Field != "" || data.Msg != "" {
+			responseError.ValidationErrors = append(responseError.ValidationErrors, data)
+		}
+	}
+
+	return responseError
+}
+
+// Error returns a description of the error including the UniFi controller details.
+func (responseError *ResponseError) Error() string {
+	message := fmt.Sprintf("response code %d", responseError.StatusCode)
+
+	var details []string
+	if responseError.Meta.Msg != "" {
+		details = append(details, responseError.Meta.Msg)
+	}
+	if responseError.Meta.Name != "" {
+		details = append(details, fmt.Sprintf("name %q", responseError.Meta.Name))
+	}
+	if responseError.Meta.RuleIndex != 0 {
+		details = append(details, fmt.Sprintf("rule index %d", responseError.Meta.RuleIndex))
+	}
+	if field := responseError.Field(); field != "" {
+		details = append(details, fmt.Sprintf("field %q", field))
+	}
+
+	if len(details) == 0 {
+		return message
+	}
+	return fmt.Sprintf("%s (%s)", message, strings.Join(details, ", "))
+}
+
+// Is reports whether the [ResponseError] matches the given target error, see the generic response
+// errors (e.g. [NotFoundError]) for the supported targets. A rejected session matches
+// [UnauthenticatedError] and a login requiring two-factor authentication matches
+// [TwoFactorRequiredError].
+func (responseError *ResponseError) Is(target error) bool {
+	msg := responseError.Meta.Msg
+
+	switch target {
+	case UnauthenticatedError:
+		return responseError.StatusCode == 401 || msg == "api.err.LoginRequired"
+	case TwoFactorRequiredError:
+		// UniFi OS controllers respond with the non-standard response code 499.
+		return responseError.StatusCode == 499 ||
+			msg == "MFA_AUTH_REQUIRED" ||
+			msg == "api.err.Ubic2faTokenRequired"
+	case NotFoundError:
+		return responseError.StatusCode == 404 ||
+			msg == "api.err.NotFound" ||
+			msg == "api.err.ObjectNotFound"
+	case DuplicateNameError:
+		return responseError.Meta.Name != "" || strings.HasSuffix(msg, "NameExisted")
+	case DuplicateRuleIndexError:
+		return responseError.Meta.RuleIndex != 0 || strings.HasSuffix(msg, "RuleIndexExisted")
+	case InvalidObjectError:
+		return msg == "api.err.InvalidObject" ||
+			msg == "api.err.InvalidPayload" ||
+			msg == "api.err.Invalid" ||
+			responseError.Meta.ValidationError != nil ||
+			len(responseError.ValidationErrors) > 0
+	default:
+		return false
+	}
+}
+
+// Field returns the field that failed validation or an empty string if no validation error was
+// included in the response.
+func (responseError *ResponseError) Field() string {
+	return responseError.validationDetails().Field
+}
+
+// Pattern returns the pattern the field that failed validation should adhere to or an empty string
+// if no validation error was included in the response.
+func (responseError *ResponseError) Pattern() string {
+	return responseError.validationDetails().Pattern
+}
+
+// Returns the first validation details included in the response.
+func (responseError *ResponseError) validationDetails() ValidationDetails {
+	if responseError.Meta.ValidationError != nil {
+		return *responseError.Meta.ValidationError
+	}
+	for _, validationError := range responseError.ValidationErrors {
+		if validationError.ValidationError.Field != "" {
+			return validationError.ValidationError
+		}
+	}
+	return ValidationDetails{}
+}
@@ -0,0 +1,189 @@
+package unifi
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestNewResponseError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		msg        string
+		field      string
+		pattern    string
+		// The number of validation errors included in the data array.
+		validationErrors int
+	}{
+		{
+			name:       "meta envelope",
+			statusCode: 400,
+			body: `{"meta":{"rc":"error","msg":"api.err.FirewallGroupNameExisted"},` +
+				`"data":[]}`,
+			msg: "api.err.FirewallGroupNameExisted",
+		},
+		{
+			name:       "meta validation error",
+			statusCode: 400,
+			body: `{"meta":{"rc":"error","msg":"api.err.Invalid",` +
+				`"validationError":{"field":"name","pattern":"^.{1,32}$"}},"data":[]}`,
+			msg:     "api.err.Invalid",
+			field:   "name",
+			pattern: "^.{1,32}$",
+		},
+		{
+			name:       "data validation errors",
+			statusCode: 400,
+			body: `{"meta":{"rc":"error","msg":"api.err.InvalidPayload"},` +
+				`"data":[{"validationError":{"field":"dst_port"},"rc":"error"},{}]}`,
+			msg:              "api.err.InvalidPayload",
+			field:            "dst_port",
+			validationErrors: 1,
+		},
+		{
+			name:       "unexpected data array",
+			statusCode: 404,
+			body:       `{"meta":{"rc":"error","msg":"api.err.NotFound"},"data":"unexpected"}`,
+			msg:        "api.err.NotFound",
+		},
+		{
+			name:       "code fallback",
+			statusCode: 499,
+			body:       `{"code":"MFA_AUTH_REQUIRED","message":"MFA required"}`,
+			msg:        "MFA_AUTH_REQUIRED",
+		},
+		{
+			name:       "message fallback",
+			statusCode: 401,
+			body:       `{"message":"Unauthorized"}`,
+			msg:        "Unauthorized",
+		},
+		{
+			name:       "no JSON",
+			statusCode: 502,
+			body:       `<html>Bad Gateway</html>`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			responseError := newResponseError(test.statusCode, []byte(test.body))
+
+			if responseError.StatusCode != test.statusCode {
+				t.Errorf("expected status code %d, got %d",
+					test.statusCode, responseError.StatusCode)
+			}
+			if responseError.Meta.Msg != test.msg {
+				t.Errorf("expected message %q, got %q", test.msg, responseError.Meta.Msg)
+			}
+			if responseError.Field() != test.field {
+				t.Errorf("expected field %q, got %q", test.field, responseError.Field())
+			}
+			if responseError.Pattern() != test.pattern {
+				t.Errorf("expected pattern %q, got %q", test.pattern, responseError.Pattern())
+			}
+			if len(responseError.ValidationErrors) != test.validationErrors {
+				t.Errorf("expected %d validation errors, got %d",
+					test.validationErrors, len(responseError.ValidationErrors))
+			}
+		})
+	}
+}
+
+func TestResponseErrorIs(t *testing.T) {
+	targets := []error{
+		UnauthenticatedError,
+		TwoFactorRequiredError,
+		NotFoundError,
+		DuplicateNameError,
+		DuplicateRuleIndexError,
+		InvalidObjectError,
+	}
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		matches    []error
+	}{
+		{name: "unauthorized", statusCode: 401, matches: []error{UnauthenticatedError}},
+		{
+			name:       "login required",
+			statusCode: 403,
+			body:       `{"meta":{"rc":"error","msg":"api.err.LoginRequired"}}`,
+			matches:    []error{UnauthenticatedError},
+		},
+		{
+			name:       "UniFi OS two-factor",
+			statusCode: 499,
+			matches:    []error{TwoFactorRequiredError},
+		},
+		{
+			name:       "classic two-factor",
+			statusCode: 400,
+			body:       `{"meta":{"rc":"error","msg":"api.err.Ubic2faTokenRequired"}}`,
+			matches:    []error{TwoFactorRequiredError},
+		},
+		{name: "not found status", statusCode: 404, matches: []error{NotFoundError}},
+		{
+			name:       "object not found",
+			statusCode: 400,
+			body:       `{"meta":{"rc":"error","msg":"api.err.ObjectNotFound"}}`,
+			matches:    []error{NotFoundError},
+		},
+		{
+			name:       "duplicate name",
+			statusCode: 400,
+			body:       `{"meta":{"rc":"error","msg":"api.err.FirewallGroupNameExisted"}}`,
+			matches:    []error{DuplicateNameError},
+		},
+		{
+			name:       "duplicate name meta",
+			statusCode: 400,
+			body:       `{"meta":{"rc":"error","msg":"api.err.Exists","name":"group"}}`,
+			matches:    []error{DuplicateNameError},
+		},
+		{
+			name:       "duplicate rule index",
+			statusCode: 400,
+			body:       `{"meta":{"rc":"error","msg":"api.err.FirewallRuleIndexExisted"}}`,
+			matches:    []error{DuplicateRuleIndexError},
+		},
+		{
+			name:       "duplicate rule index meta",
+			statusCode: 400,
+			body:       `{"meta":{"rc":"error","msg":"api.err.Exists","rule_index":2000}}`,
+			matches:    []error{DuplicateRuleIndexError},
+		},
+		{
+			name:       "invalid object",
+			statusCode: 400,
+			body:       `{"meta":{"rc":"error","msg":"api.err.InvalidObject"}}`,
+			matches:    []error{InvalidObjectError},
+		},
+		{
+			name:       "validation error",
+			statusCode: 400,
+			body: `{"meta":{"rc":"error","msg":"api.err.Unknown"},` +
+				`"data":[{"validationError":{"field":"name"}}]}`,
+			matches: []error{InvalidObjectError},
+		},
+		{name: "server error", statusCode: 500},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Wrap the error like the requests do, the targets must match through the wrapping.
+			responseError := newResponseError(test.statusCode, []byte(test.body))
+			err := fmt.Errorf("request failed: %w", responseError)
+
+			for _, target := range targets {
+				expected := slices.Contains(test.matches, target)
+				if errors.Is(err, target) != expected {
+					t.Errorf("expected errors.Is(%q) to be %t", target, expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// A restResource describes a `rest/<name>` endpoint of a [Site], which manages objects of type T
+// and returns responses of type R (e.g. [FirewallGroup] and [FirewallGroupResponse]).
+// It implements the create, list, get, update and delete requests, so adding a new `rest/` object
+// only requires declaring its resource.
+type restResource[T any, R any] struct {
+	// The endpoint path e.g. "rest/firewallgroup".
+	path string
+	// The name of the object used in error messages e.g. "firewall group".
+	name string
+	// The plural name of the object used in error messages e.g. "firewall groups".
+	pluralName string
+}
+
+// Creates a new object linked to the given [Site] using the given object data.
+// It will return an error if the creation of the object failed.
+func (resource restResource[T, R]) create(ctx context.Context, site *Site, object T) (R, error) {
+	return resource.execute(
+		ctx,
+		site,
+		http.MethodPost,
+		"",
+		object,
+		fmt.Sprintf("creating %s", resource.name),
+	)
+}
+
+// Returns all objects linked to the given [Site].
+// It will return an error if it fails to fetch the objects.
+func (resource restResource[T, R]) getAll(ctx context.Context, site *Site) (R, error) {
+	return resource.execute(
+		ctx,
+		site,
+		http.MethodGet,
+		"",
+		nil,
+		fmt.Sprintf("retreiving %s", resource.pluralName),
+	)
+}
+
+// Returns the object linked to the given ID and [Site].
+// It will return an error if it fails to fetch the object.
+func (resource restResource[T, R]) get(ctx context.Context, site *Site, id string) (R, error) {
+	return resource.execute(
+		ctx,
+		site,
+		http.MethodGet,
+		id,
+		nil,
+		fmt.Sprintf("retreiving %s", resource.name),
+	)
+}
+
+// Updates the object linked to the given ID and [Site] using the given object data.
+// It will return an error if the update of the object failed.
+func (resource restResource[T, R]) update(
+	ctx context.Context,
+	site *Site,
+	id string,
+	object T,
+) (R, error) {
+	return resource.updateFields(ctx, site, id, object)
+}
+
+// Updates only the given fields (e.g. a map or a struct containing a subset of the fields) of the
+// object linked to the given ID and [Site], this allows setting fields to their zero value which
+// are omitted by the object data (e.g. disabling an object).
+// It will return an error if the update of the object failed.
+func (resource restResource[T, R]) updateFields(
+	ctx context.Context,
+	site *Site,
+	id string,
+	fields any,
+) (R, error) {
+	return resource.execute(
+		ctx,
+		site,
+		http.MethodPut,
+		id,
+		fields,
+		fmt.Sprintf("%s update", resource.name),
+	)
+}
+
+// Deletes the object linked to the given ID and [Site].
+// It will return an error if the deletion of the object failed.
+func (resource restResource[T, R]) delete(ctx context.Context, site *Site, id string) (R, error) {
+	return resource.execute(
+		ctx,
+		site,
+		http.MethodDelete,
+		id,
+		nil,
+		fmt.Sprintf("deleting %s", resource.name),
+	)
+}
+
+// Executes a request with the given method and body to the endpoint of the given [Site] and ID (if
+// not empty) and parses the response. It will return an error describing the given operation if
+// the request fails.
+func (resource restResource[T, R]) execute(
+	ctx context.Context,
+	site *Site,
+	method string,
+	id string,
+	body any,
+	operation string,
+) (R, error) {
+	var responseData R
+	endpointUrl := site.createEndpointUrl(resource.path, id)
+
+	_, err := site.controller.execute(ctx, method, endpointUrl, body, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("%s failed: %w", operation, err)
+	}
+
+	return responseData, nil
+}
@@ -0,0 +1,119 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// A RetryPolicy determines if and how requests that failed due to a transient error are retried.
+// The zero value disables retries, use [DefaultRetryPolicy] for sensible defaults.
+type RetryPolicy struct {
+	// The maximum number of attempts (including the first attempt), 0 or 1 disables retries.
+	MaxAttempts int
+	// The backoff before the first retry, the backoff is doubled for every following retry.
+	// A random jitter of up to half the backoff is subtracted to spread out retries.
+	InitialBackoff time.Duration
+	// The maximum backoff between two attempts (0 means no maximum).
+	MaxBackoff time.Duration
+	// The response codes that indicate a transient failure, e.g. 502 and 503 returned by the
+	// UniFi OS proxy while the UniFi Network application is restarting.
+	RetryableStatusCodes []int
+	// Indicates whether requests using a non-idempotent method (POST and PATCH) are retried as
+	// well, by default only idempotent requests are retried.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy returns a [RetryPolicy] which makes at most 3 attempts, starting with a
+// backoff of 500 milliseconds up to 10 seconds and retries idempotent requests that failed due to
+// a connection error or a 429, 502, 503 or 504 response code.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// Verifies the retry policy is valid, it returns an error describing the first invalid setting.
+func (policy RetryPolicy) validate() error {
+	if policy.MaxAttempts < 0 {
+		return errors.New("retry max attempts can not be smaller than 0")
+	}
+	if policy.InitialBackoff < 0 {
+		return errors.New("retry initial backoff can not be smaller than 0")
+	}
+	if policy.MaxBackoff < 0 {
+		return errors.New("retry max backoff can not be smaller than 0 (no maximum)")
+	}
+	return nil
+}
+
+// Returns whether a request with the given method, which resulted in the given response or error,
+// should be retried.
+func (policy RetryPolicy) shouldRetry(
+	ctx context.Context,
+	method string,
+	res *http.Response,
+	err error,
+) bool {
+	// Never retry when the request was cancelled or its deadline was exceeded.
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if !policy.RetryNonIdempotent && (method == http.MethodPost || method == http.MethodPatch) {
+		return false
+	}
+
+	if err != nil {
+		// Only connection errors are transient, other errors (e.g. authentication errors) are not.
+		var urlError *url.Error
+		return errors.As(err, &urlError)
+	}
+
+	return slices.Contains(policy.RetryableStatusCodes, res.StatusCode)
+}
+
+// Returns the backoff before the given retry (starting at 1), including a random jitter.
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	backoff := policy.InitialBackoff
+	for i := 1; i < retry; i++ {
+		backoff *= 2
+		if policy.MaxBackoff > 0 && backoff >= policy.MaxBackoff {
+			break
+		}
+	}
+	if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+		backoff = policy.MaxBackoff
+	}
+
+	if jitter := int64(backoff / 2); jitter > 0 {
+		backoff -= time.Duration(rand.Int63n(jitter))
+	}
+	return backoff
+}
+
+// Waits for the backoff before the given retry (starting at 1).
+// It returns an error if the context is done before the backoff has passed.
+func (policy RetryPolicy) wait(ctx context.Context, retry int) error {
+	timer := time.NewTimer(policy.backoff(retry))
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
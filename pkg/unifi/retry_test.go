@@ -0,0 +1,132 @@
+package unifi
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// Builds a [Controller] for the given [testServer] authenticated using an API key and the given
+// retry policy.
+func newRetryTestController(t *testing.T, server *testServer, policy RetryPolicy) *Controller {
+	t.Helper()
+
+	builder := ControllerBuilder{}
+	controller, err := builder.
+		SetBaseUrl(server.URL).
+		SetControllerType(ControllerTypeClassic).
+		SetApiKey("api-key").
+		SetRetryPolicy(policy).
+		Build()
+	if err != nil {
+		t.Fatalf("building controller failed: %s", err)
+	}
+
+	return controller
+}
+
+// Returns a [RetryPolicy] retrying 503 responses with a negligible backoff.
+func fastRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:          3,
+		InitialBackoff:       time.Millisecond,
+		MaxBackoff:           5 * time.Millisecond,
+		RetryableStatusCodes: []int{http.StatusServiceUnavailable},
+	}
+}
+
+// Responds with 503 to the given number of requests and with an empty data array afterwards.
+func unavailableHandler(server *testServer, failures int32) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if server.requests.Load() <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}
+}
+
+func TestRetryTransientStatusCode(t *testing.T) {
+	server := newTestServer(t)
+	server.handler = unavailableHandler(server, 2)
+	site := newRetryTestController(t, server, fastRetryPolicy()).CreateDefaultSite()
+
+	_, err := site.GetAllFirewallGroups()
+	if err != nil {
+		t.Fatalf("expected request to succeed after retrying, got %s", err)
+	}
+	if requests := server.requests.Load(); requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestRetryStopsAfterMaxAttempts(t *testing.T) {
+	server := newTestServer(t)
+	server.handler = unavailableHandler(server, 10)
+	site := newRetryTestController(t, server, fastRetryPolicy()).CreateDefaultSite()
+
+	_, err := site.GetAllFirewallGroups()
+	var responseError *ResponseError
+	if !errors.As(err, &responseError) || responseError.StatusCode != 503 {
+		t.Fatalf("expected response error with status code 503, got %v", err)
+	}
+	if requests := server.requests.Load(); requests != 3 {
+		t.Errorf("expected 3 requests, got %d", requests)
+	}
+}
+
+func TestRetryExcludesPostByDefault(t *testing.T) {
+	server := newTestServer(t)
+	server.handler = unavailableHandler(server, 10)
+	site := newRetryTestController(t, server, fastRetryPolicy()).CreateDefaultSite()
+
+	_, err := site.CreateFirewallGroup(FirewallGroup{Name: "group"})
+	var responseError *ResponseError
+	if !errors.As(err, &responseError) || responseError.StatusCode != 503 {
+		t.Fatalf("expected response error with status code 503, got %v", err)
+	}
+	if requests := server.requests.Load(); requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	server := newTestServer(t)
+	server.handler = unavailableHandler(server, 1)
+	policy := fastRetryPolicy()
+	policy.RetryNonIdempotent = true
+	site := newRetryTestController(t, server, policy).CreateDefaultSite()
+
+	_, err := site.CreateFirewallGroup(FirewallGroup{Name: "group"})
+	if err != nil {
+		t.Fatalf("expected request to succeed after retrying, got %s", err)
+	}
+	if requests := server.requests.Load(); requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	tests := []struct {
+		retry int
+		max   time.Duration
+	}{
+		{retry: 1, max: 100 * time.Millisecond},
+		{retry: 2, max: 200 * time.Millisecond},
+		{retry: 3, max: 400 * time.Millisecond},
+		{retry: 5, max: time.Second},
+		{retry: 100, max: time.Second},
+	}
+	for _, test := range tests {
+		for i := 0; i < 20; i++ {
+			backoff := policy.backoff(test.retry)
+			if backoff > test.max || backoff <= test.max/2 {
+				t.Errorf("backoff of retry %d is %s, expected (%s, %s]",
+					test.retry, backoff, test.max/2, test.max)
+			}
+		}
+	}
+}
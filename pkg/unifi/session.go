@@ -0,0 +1,271 @@
+package unifi
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"time"
+)
+
+// A Session is the exportable representation of an authenticated session of a [Controller], it
+// can be used to restore the session in another process to avoid a login on every run.
+// A Session contains secrets and should be stored securely, see [Controller.SaveSession].
+type Session struct {
+	// The URL of the UniFi controller the session belongs to.
+	BaseUrl string `json:"base_url"`
+	// The type of the UniFi controller the session belongs to.
+	ControllerType ControllerType `json:"controller_type,omitempty"`
+	// The value of the 'TOKEN' cookie.
+	Token string `json:"token"`
+	// The CSRF token.
+	CsrfToken string `json:"csrf_token"`
+	// The expiration time of the 'TOKEN' cookie (zero if the cookie has no expiration).
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+// ExportSession returns the current session of the [Controller].
+// It returns an [UnauthenticatedError] if the [Controller] has not received authentication.
+func (controller *Controller) ExportSession() (Session, error) {
+	controller.sessionMutex.RLock()
+	defer controller.sessionMutex.RUnlock()
+
+	if controller.cookie == nil || controller.csrfToken == "" {
+		return Session{}, UnauthenticatedError
+	}
+
+	return Session{
+		BaseUrl:        controller.baseUrl,
+		ControllerType: controller.controllerType,
+		Token:          controller.cookie.Value,
+		CsrfToken:      controller.csrfToken,
+		Expires:        controller.cookie.Expires,
+	}, nil
+}
+
+// WriteSession writes the current session of the [Controller] as (unencrypted) JSON to the given
+// writer. It returns an error if there is no session or writing fails.
+func (controller *Controller) WriteSession(writer io.Writer) error {
+	session, err := controller.ExportSession()
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(writer).Encode(session)
+}
+
+// WriteEncryptedSession writes the current session of the [Controller] encrypted (AES-GCM) with
+// the given key to the given writer. The key must be 16, 24 or 32 bytes long to select AES-128,
+// AES-192 or AES-256. It returns an error if there is no session, the key is invalid or writing
+// fails.
+func (controller *Controller) WriteEncryptedSession(writer io.Writer, key []byte) error {
+	var plaintext bytes.Buffer
+	err := controller.WriteSession(&plaintext)
+	if err != nil {
+		return err
+	}
+
+	aead, err := newSessionCipher(key)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(aead.Seal(nonce, nonce, plaintext.Bytes(), nil))
+	return err
+}
+
+// SaveSession writes the current session of the [Controller] encrypted with the given key (see
+// [Controller.WriteEncryptedSession]) to the file at the given path, the file is only readable by
+// the current user. It returns an error if there is no session or the file could not be written.
+func (controller *Controller) SaveSession(path string, key []byte) error {
+	var ciphertext bytes.Buffer
+	err := controller.WriteEncryptedSession(&ciphertext, key)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, ciphertext.Bytes(), 0600)
+}
+
+// ReadSession reads a session written by [Controller.WriteSession] from the given reader.
+// It returns an error if reading or parsing fails.
+func ReadSession(reader io.Reader) (Session, error) {
+	session := Session{}
+	err := json.NewDecoder(reader).Decode(&session)
+	if err != nil {
+		return Session{}, fmt.Errorf("failed to parse session: %w", err)
+	}
+
+	return session, nil
+}
+
+// ReadEncryptedSession reads a session written by [Controller.WriteEncryptedSession] from the
+// given reader and decrypts it using the given key.
+// It returns an error if reading, decrypting or parsing fails.
+func ReadEncryptedSession(reader io.Reader, key []byte) (Session, error) {
+	ciphertext, err := io.ReadAll(reader)
+	if err != nil {
+		return Session{}, err
+	}
+
+	aead, err := newSessionCipher(key)
+	if err != nil {
+		return Session{}, err
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return Session{}, errors.New("encrypted session is too short")
+	}
+
+	nonce := ciphertext[:aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, ciphertext[aead.NonceSize():], nil)
+	if err != nil {
+		return Session{}, errors.New("failed to decrypt session, invalid key or corrupt session")
+	}
+
+	return ReadSession(bytes.NewReader(plaintext))
+}
+
+// RestoreSession replaces the session of the [Controller] with the given session and validates
+// it using [Controller.AssertAuthenticated]. If the session is no longer valid, the [Controller]
+// authenticates using its [CredentialProvider] instead (see [Controller.Authenticate]).
+// It returns an error if the session belongs to another UniFi controller, or if the session is
+// invalid and authentication fails.
+func (controller *Controller) RestoreSession(session Session) error {
+	return controller.RestoreSessionWithContext(context.Background(), session)
+}
+
+// RestoreSessionWithContext is the same as [Controller.RestoreSession] but uses the given context
+// for the login request (if required).
+func (controller *Controller) RestoreSessionWithContext(
+	ctx context.Context,
+	session Session,
+) error {
+	err := controller.verifySessionOwner(session)
+	if err != nil {
+		return err
+	}
+
+	controller.sessionMutex.Lock()
+	if session.Token == "" || session.CsrfToken == "" {
+		controller.cookie = nil
+		controller.csrfToken = ""
+	} else {
+		controller.cookie = &http.Cookie{
+			Name:    "TOKEN",
+			Value:   session.Token,
+			Expires: session.Expires,
+		}
+		controller.csrfToken = session.CsrfToken
+	}
+	controller.sessionGeneration++
+	controller.sessionMutex.Unlock()
+
+	err = controller.AssertAuthenticated()
+	if err == nil {
+		return nil
+	}
+
+	return controller.authenticateInstead(ctx, err)
+}
+
+// LoadSession restores the session saved by [Controller.SaveSession] at the given path using the
+// given key (see [Controller.RestoreSession]). If the file does not exist or the saved session is
+// no longer usable (e.g. the key was rotated, the file is corrupt or the session belongs to
+// another UniFi controller), the [Controller] authenticates using its [CredentialProvider] instead
+// (see [Controller.Authenticate]).
+// It returns an error if the key is invalid, the file could not be opened or if authentication
+// fails. Without a credential provider the reason the session could not be restored is returned.
+func (controller *Controller) LoadSession(path string, key []byte) error {
+	return controller.LoadSessionWithContext(context.Background(), path, key)
+}
+
+// LoadSessionWithContext is the same as [Controller.LoadSession] but uses the given context for
+// the login request (if required).
+func (controller *Controller) LoadSessionWithContext(
+	ctx context.Context,
+	path string,
+	key []byte,
+) error {
+	// An invalid key is a configuration error, not an unusable session.
+	_, err := newSessionCipher(key)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return controller.AuthenticateWithContext(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	session, err := ReadEncryptedSession(file, key)
+	if err != nil {
+		return controller.authenticateInstead(ctx, err)
+	}
+
+	err = controller.verifySessionOwner(session)
+	if err != nil {
+		return controller.authenticateInstead(ctx, err)
+	}
+
+	return controller.RestoreSessionWithContext(ctx, session)
+}
+
+// Verifies the given session belongs to the UniFi controller of the [Controller] (same base URL
+// and controller type). It returns an error describing both UniFi controllers if it does not.
+func (controller *Controller) verifySessionOwner(session Session) error {
+	if session.BaseUrl != controller.baseUrl ||
+		session.ControllerType.normalize() != controller.controllerType {
+		return fmt.Errorf(
+			"session belongs to %s controller %q instead of %s controller %q",
+			session.ControllerType.normalize(),
+			session.BaseUrl,
+			controller.controllerType,
+			controller.baseUrl,
+		)
+	}
+	return nil
+}
+
+// Authenticates using the [CredentialProvider] of the [Controller] because a session could not be
+// restored for the given reason. It returns the given reason if no credential provider is set or
+// an error if the authentication fails.
+func (controller *Controller) authenticateInstead(ctx context.Context, reason error) error {
+	controller.sessionMutex.RLock()
+	hasCredentialProvider := controller.credentialProvider != nil
+	controller.sessionMutex.RUnlock()
+	if !hasCredentialProvider {
+		return reason
+	}
+
+	return controller.AuthenticateWithContext(ctx)
+}
+
+// Creates the AES-GCM cipher used to encrypt sessions using the given key.
+// It returns an error if the key is not 16, 24 or 32 bytes long.
+func newSessionCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid session key: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,221 @@
+package unifi
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Returns a session key of the given length.
+func testSessionKey(length int, fill byte) []byte {
+	return bytes.Repeat([]byte{fill}, length)
+}
+
+func TestEncryptedSessionRoundTrip(t *testing.T) {
+	server := newTestServer(t)
+	controller := newAuthenticatedTestController(t, server)
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+	controller.cookie.Expires = expires
+
+	for _, length := range []int{16, 24, 32} {
+		key := testSessionKey(length, 1)
+		var buffer bytes.Buffer
+		err := controller.WriteEncryptedSession(&buffer, key)
+		if err != nil {
+			t.Fatalf("writing session with %d byte key failed: %s", length, err)
+		}
+
+		session, err := ReadEncryptedSession(bytes.NewReader(buffer.Bytes()), key)
+		if err != nil {
+			t.Fatalf("reading session with %d byte key failed: %s", length, err)
+		}
+		expected := Session{
+			BaseUrl:        server.URL,
+			ControllerType: ControllerTypeClassic,
+			Token:          "rejected-token",
+			CsrfToken:      "rejected-csrf-token",
+			Expires:        expires,
+		}
+		if !session.Expires.Equal(expected.Expires) {
+			t.Errorf("expected expiration %s, got %s", expected.Expires, session.Expires)
+		}
+		session.Expires = expected.Expires
+		if session != expected {
+			t.Errorf("expected session %+v, got %+v", expected, session)
+		}
+
+		_, err = ReadEncryptedSession(bytes.NewReader(buffer.Bytes()), testSessionKey(length, 2))
+		if err == nil {
+			t.Errorf("expected an error when decrypting with the wrong %d byte key", length)
+		}
+	}
+}
+
+func TestEncryptedSessionInvalidKey(t *testing.T) {
+	controller := newAuthenticatedTestController(t, newTestServer(t))
+
+	err := controller.WriteEncryptedSession(&bytes.Buffer{}, testSessionKey(15, 1))
+	if err == nil {
+		t.Error("expected an error for a 15 byte key")
+	}
+}
+
+func TestLoadSessionRestoresSession(t *testing.T) {
+	server := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "session")
+	key := testSessionKey(32, 1)
+	err := newAuthenticatedTestController(t, server).SaveSession(path, key)
+	if err != nil {
+		t.Fatalf("saving session failed: %s", err)
+	}
+
+	controller := newExpiredTestController(t, server)
+	err = controller.LoadSession(path, key)
+	if err != nil {
+		t.Fatalf("loading session failed: %s", err)
+	}
+	if logins := server.logins.Load(); logins != 0 {
+		t.Errorf("expected no login, got %d", logins)
+	}
+	if controller.cookie.Value != "rejected-token" {
+		t.Errorf("expected restored token, got %s", controller.cookie.Value)
+	}
+}
+
+func TestLoadSessionFallsBackToLogin(t *testing.T) {
+	tests := []struct {
+		name string
+		// Prepares the session file at the given path saved with the given key.
+		prepare func(t *testing.T, server *testServer, path string, key []byte)
+	}{
+		{
+			name:    "missing file",
+			prepare: func(*testing.T, *testServer, string, []byte) {},
+		},
+		{
+			name: "rotated key",
+			prepare: func(t *testing.T, server *testServer, path string, _ []byte) {
+				err := newAuthenticatedTestController(t, server).
+					SaveSession(path, testSessionKey(32, 2))
+				if err != nil {
+					t.Fatalf("saving session failed: %s", err)
+				}
+			},
+		},
+		{
+			name: "corrupt file",
+			prepare: func(t *testing.T, _ *testServer, path string, _ []byte) {
+				err := os.WriteFile(path, []byte("corrupt"), 0600)
+				if err != nil {
+					t.Fatalf("writing session file failed: %s", err)
+				}
+			},
+		},
+		{
+			name: "other controller",
+			prepare: func(t *testing.T, server *testServer, path string, key []byte) {
+				other := newAuthenticatedTestController(t, server)
+				other.baseUrl = "https://other-unifi"
+				err := other.SaveSession(path, key)
+				if err != nil {
+					t.Fatalf("saving session failed: %s", err)
+				}
+			},
+		},
+		{
+			name: "expired session",
+			prepare: func(t *testing.T, server *testServer, path string, key []byte) {
+				err := newExpiredTestController(t, server).SaveSession(path, key)
+				if err != nil {
+					t.Fatalf("saving session failed: %s", err)
+				}
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+			path := filepath.Join(t.TempDir(), "session")
+			key := testSessionKey(32, 1)
+			test.prepare(t, server, path, key)
+
+			controller := newExpiredTestController(t, server)
+			err := controller.LoadSession(path, key)
+			if err != nil {
+				t.Fatalf("loading session failed: %s", err)
+			}
+			if logins := server.logins.Load(); logins != 1 {
+				t.Errorf("expected 1 login, got %d", logins)
+			}
+			if err = controller.AssertAuthenticated(); err != nil {
+				t.Errorf("expected controller to be authenticated, got %s", err)
+			}
+		})
+	}
+}
+
+func TestLoadSessionWithoutCredentialProvider(t *testing.T) {
+	server := newTestServer(t)
+	path := filepath.Join(t.TempDir(), "session")
+	err := newAuthenticatedTestController(t, server).SaveSession(path, testSessionKey(32, 2))
+	if err != nil {
+		t.Fatalf("saving session failed: %s", err)
+	}
+
+	controller := newExpiredTestController(t, server)
+	controller.SetCredentialProvider(nil)
+	err = controller.LoadSession(path, testSessionKey(32, 1))
+	if err == nil {
+		t.Fatal("expected an error when decrypting with the wrong key")
+	}
+	if logins := server.logins.Load(); logins != 0 {
+		t.Errorf("expected no login, got %d", logins)
+	}
+}
+
+func TestLoadSessionInvalidKey(t *testing.T) {
+	server := newTestServer(t)
+	controller := newExpiredTestController(t, server)
+
+	err := controller.LoadSession(filepath.Join(t.TempDir(), "session"), testSessionKey(15, 1))
+	if err == nil {
+		t.Fatal("expected an error for a 15 byte key")
+	}
+	if logins := server.logins.Load(); logins != 0 {
+		t.Errorf("expected no login, got %d", logins)
+	}
+}
+
+// Ensures the restored cookie is sent with requests.
+func TestRestoreSessionAuthorizesRequests(t *testing.T) {
+	server := newTestServer(t)
+	server.handler = func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("TOKEN")
+		if err != nil || cookie.Value != "restored-token" ||
+			r.Header.Get("X-CSRF-Token") != "restored-csrf-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+	}
+	controller := newExpiredTestController(t, server)
+	controller.SetCredentialProvider(nil)
+
+	err := controller.RestoreSession(Session{
+		BaseUrl:        server.URL,
+		ControllerType: ControllerTypeClassic,
+		Token:          "restored-token",
+		CsrfToken:      "restored-csrf-token",
+	})
+	if err != nil {
+		t.Fatalf("restoring session failed: %s", err)
+	}
+
+	_, err = controller.CreateDefaultSite().GetAllFirewallGroups()
+	if err != nil {
+		t.Errorf("request using restored session failed: %s", err)
+	}
+}
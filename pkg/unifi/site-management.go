@@ -0,0 +1,233 @@
+package unifi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// SiteInfoResponse is the representation of a response of a site request.
+type SiteInfoResponse struct {
+	Meta Meta                   `json:"meta"`
+	Data []SiteInfoResponseData `json:"data"`
+}
+
+// SiteInfoResponseData is the representation of the data inside the data array of the
+// [SiteInfoResponse]. It contains either [SiteInfo] or [DataValidationError] based on whether the
+// request succeeded.
+type SiteInfoResponseData struct {
+	*SiteInfo
+	*DataValidationError
+}
+
+// SiteInfo is the representation of a site of a UniFi controller.
+type SiteInfo struct {
+	// The site ID.
+	Id string `json:"_id,omitempty"`
+	// The internal site name, used to access the site (see [Controller.CreateSite]).
+	Name string `json:"name,omitempty"`
+	// The site description, this is the site name shown in the UI.
+	Desc string `json:"desc,omitempty"`
+	// The role of the current user on the site e.g. admin, readonly.
+	Role string `json:"role,omitempty"`
+	// The ID of the hidden attributes of the site e.g. "default" for the default site.
+	AttrHiddenId string `json:"attr_hidden_id,omitempty"`
+	// Indicates whether the site can not be deleted (e.g. the default site).
+	AttrNoDelete bool `json:"attr_no_delete,omitempty"`
+	// The number of devices of the site.
+	DeviceCount int `json:"device_count,omitempty"`
+	// The health summary per subsystem of the site (only included by
+	// [Controller.GetAllSitesWithHealth]).
+	Health []SiteHealth `json:"health,omitempty"`
+}
+
+// SiteHealth is the representation of the health summary of a subsystem of a site.
+type SiteHealth struct {
+	// The subsystem, options:
+	//	- wlan: The wireless network (access points).
+	//	- lan: The wired network (switches).
+	//	- wan: The internet connection (gateway).
+	//	- www: The internet connectivity.
+	//	- vpn: The VPN servers.
+	Subsystem string `json:"subsystem,omitempty"`
+	// The subsystem status e.g. ok, warning, error, unknown.
+	Status string `json:"status,omitempty"`
+	// The number of connected users.
+	NumUser int `json:"num_user,omitempty"`
+	// The number of connected guests.
+	NumGuest int `json:"num_guest,omitempty"`
+	// The number of connected IoT clients.
+	NumIot int `json:"num_iot,omitempty"`
+	// The number of access points.
+	NumAp int `json:"num_ap,omitempty"`
+	// The number of switches.
+	NumSw int `json:"num_sw,omitempty"`
+	// The number of gateways.
+	NumGw int `json:"num_gw,omitempty"`
+	// The number of adopted devices.
+	NumAdopted int `json:"num_adopted,omitempty"`
+	// The number of disabled devices.
+	NumDisabled int `json:"num_disabled,omitempty"`
+	// The number of disconnected devices.
+	NumDisconnected int `json:"num_disconnected,omitempty"`
+	// The number of devices pending adoption.
+	NumPending int `json:"num_pending,omitempty"`
+	// The transmit rate in bytes per second.
+	TxBytesR float64 `json:"tx_bytes-r,omitempty"`
+	// The receive rate in bytes per second.
+	RxBytesR float64 `json:"rx_bytes-r,omitempty"`
+	// The WAN IP address (only set for the wan subsystem).
+	WanIp string `json:"wan_ip,omitempty"`
+}
+
+// siteManagerCommand is the representation of the body of a site manager command.
+type siteManagerCommand struct {
+	Cmd  string `json:"cmd"`
+	Desc string `json:"desc,omitempty"`
+	Site string `json:"site,omitempty"`
+	Mac  string `json:"mac,omitempty"`
+}
+
+// GetAllSites returns all sites of the UniFi controller the current user has access to.
+// It will return an error if it fails to fetch the sites.
+func (controller *Controller) GetAllSites() (SiteInfoResponse, error) {
+	return controller.GetAllSitesWithContext(context.Background())
+}
+
+// GetAllSitesWithContext is the same as [Controller.GetAllSites] but uses the given context for
+// the request.
+func (controller *Controller) GetAllSitesWithContext(
+	ctx context.Context,
+) (SiteInfoResponse, error) {
+	endpointUrl := controller.createEndpointUrl("self/sites")
+	responseData := SiteInfoResponse{}
+
+	_, err := controller.execute(ctx, http.MethodGet, endpointUrl, nil, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("retreiving sites failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// GetAllSitesWithHealth returns all sites of the UniFi controller including their health summary.
+// It will return an error if it fails to fetch the sites.
+func (controller *Controller) GetAllSitesWithHealth() (SiteInfoResponse, error) {
+	return controller.GetAllSitesWithHealthWithContext(context.Background())
+}
+
+// GetAllSitesWithHealthWithContext is the same as [Controller.GetAllSitesWithHealth] but uses the
+// given context for the request.
+func (controller *Controller) GetAllSitesWithHealthWithContext(
+	ctx context.Context,
+) (SiteInfoResponse, error) {
+	endpointUrl := controller.createEndpointUrl("stat/sites")
+	responseData := SiteInfoResponse{}
+
+	_, err := controller.execute(ctx, http.MethodGet, endpointUrl, nil, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("retreiving sites failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// GetSiteByDescription returns a reference to the [Site] with the given description (the site
+// name shown in the UI) linked to this [Controller].
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no site has the given
+// description or an error if it fails to fetch the sites.
+func (controller *Controller) GetSiteByDescription(description string) (*Site, error) {
+	return controller.GetSiteByDescriptionWithContext(context.Background(), description)
+}
+
+// GetSiteByDescriptionWithContext is the same as [Controller.GetSiteByDescription] but uses the
+// given context for the request.
+func (controller *Controller) GetSiteByDescriptionWithContext(
+	ctx context.Context,
+	description string,
+) (*Site, error) {
+	response, err := controller.GetAllSitesWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, responseData := range response.Data {
+		if responseData.SiteInfo != nil && responseData.Desc == description {
+			return &Site{
+				name:       responseData.Name,
+				controller: controller,
+			}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: no site with description %q", NotFoundError, description)
+}
+
+// AddSite creates a new site with the given description (the site name shown in the UI) on the
+// UniFi controller, the internal site name is generated by the UniFi controller and included in
+// the response. It will return an error if the creation of the site failed.
+func (controller *Controller) AddSite(description string) (SiteInfoResponse, error) {
+	return controller.AddSiteWithContext(context.Background(), description)
+}
+
+// AddSiteWithContext is the same as [Controller.AddSite] but uses the given context for the
+// request.
+func (controller *Controller) AddSiteWithContext(
+	ctx context.Context,
+	description string,
+) (SiteInfoResponse, error) {
+	responseData := SiteInfoResponse{}
+	command := siteManagerCommand{Cmd: "add-site", Desc: description}
+
+	err := controller.CreateDefaultSite().executeCommand(ctx, "sitemgr", command, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("creating site failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// DeleteSite deletes the site linked to the given ID (not the internal site name) from the UniFi
+// controller. It will return an error if the deletion of the site failed.
+func (controller *Controller) DeleteSite(id string) (SiteInfoResponse, error) {
+	return controller.DeleteSiteWithContext(context.Background(), id)
+}
+
+// DeleteSiteWithContext is the same as [Controller.DeleteSite] but uses the given context for the
+// request.
+func (controller *Controller) DeleteSiteWithContext(
+	ctx context.Context,
+	id string,
+) (SiteInfoResponse, error) {
+	responseData := SiteInfoResponse{}
+	command := siteManagerCommand{Cmd: "delete-site", Site: id}
+
+	err := controller.CreateDefaultSite().executeCommand(ctx, "sitemgr", command, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("deleting site failed: %w", err)
+	}
+
+	return responseData, nil
+}
+
+// Rename updates the description (the site name shown in the UI) of this [Site], the internal
+// site name does not change. It will return an error if the update of the site failed.
+func (site *Site) Rename(description string) (SiteInfoResponse, error) {
+	return site.RenameWithContext(context.Background(), description)
+}
+
+// RenameWithContext is the same as [Site.Rename] but uses the given context for the request.
+func (site *Site) RenameWithContext(
+	ctx context.Context,
+	description string,
+) (SiteInfoResponse, error) {
+	responseData := SiteInfoResponse{}
+	command := siteManagerCommand{Cmd: "update-site", Desc: description}
+
+	err := site.executeCommand(ctx, "sitemgr", command, &responseData)
+	if err != nil {
+		return responseData, fmt.Errorf("site update failed: %w", err)
+	}
+
+	return responseData, nil
+}
@@ -1,8 +1,10 @@
 package unifi
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
 )
 
 // A Site is used to access site specific requests of a UniFi controller.
@@ -23,6 +25,11 @@ func (site *Site) SetController(controller *Controller) error {
 	return nil
 }
 
+// Name returns the name of the [Site] (as defined in the UniFi controller).
+func (site *Site) Name() string {
+	return site.name
+}
+
 // SetName updates the name of the [Site] to the given name.
 // It will return an error if the given name is empty
 func (site *Site) SetName(name string) error {
@@ -36,13 +43,19 @@ func (site *Site) SetName(name string) error {
 // Returns the endpoint for the given path and ID (if not empty) based on the [Site] and
 // [Controller] type.
 func (site *Site) createEndpointUrl(path string, id string) string {
-	var endpoint string
-	switch site.controller.controllerType {
-	case "UDM-Pro":
-		endpoint = fmt.Sprintf("%s/proxy/network/api/s/%s", site.controller.baseUrl, site.name)
-	default:
-		endpoint = fmt.Sprintf("%s/api/s/%s", site.controller.baseUrl, site.name)
+	endpoint := site.controller.createEndpointUrl(fmt.Sprintf("s/%s", site.name))
+
+	if id == "" {
+		return fmt.Sprintf("%s/%s", endpoint, path)
+	} else {
+		return fmt.Sprintf("%s/%s/%s", endpoint, path, id)
 	}
+}
+
+// Returns the endpoint of the v2 API for the given path and ID (if not empty) based on the [Site]
+// and [Controller] type.
+func (site *Site) createV2EndpointUrl(path string, id string) string {
+	endpoint := site.controller.createV2EndpointUrl(fmt.Sprintf("site/%s", site.name))
 
 	if id == "" {
 		return fmt.Sprintf("%s/%s", endpoint, path)
@@ -50,3 +63,18 @@ func (site *Site) createEndpointUrl(path string, id string) string {
 		return fmt.Sprintf("%s/%s/%s", endpoint, path, id)
 	}
 }
+
+// Sends the given command to the given command manager (e.g. `sitemgr`) of the [Site]. If
+// responseData is set the response body will be parsed and stored in this variable.
+// It will return an error if the command fails.
+func (site *Site) executeCommand(
+	ctx context.Context,
+	manager string,
+	command any,
+	responseData any,
+) error {
+	endpointUrl := site.createEndpointUrl(fmt.Sprintf("cmd/%s", manager), "")
+
+	_, err := site.controller.execute(ctx, http.MethodPost, endpointUrl, command, responseData)
+	return err
+}
@@ -0,0 +1,221 @@
+package unifi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// NotSupportedError is returned when a feature is not supported by the version of the UniFi
+// Network application, see [SystemInfo.Require].
+var NotSupportedError = errors.New("not supported by this controller version")
+
+// Capability is a feature that is only supported by certain versions of the UniFi Network
+// application.
+type Capability string
+
+// The known capabilities.
+const (
+	// CapabilityTrafficRules indicates support for traffic rules (v2 API).
+	CapabilityTrafficRules Capability = "traffic-rules"
+	// CapabilityTrafficRoutes indicates support for traffic routes (v2 API).
+	CapabilityTrafficRoutes Capability = "traffic-routes"
+	// CapabilityZoneBasedFirewall indicates support for firewall zones and policies (v2 API).
+	CapabilityZoneBasedFirewall Capability = "zone-based-firewall"
+	// CapabilityIntegrationApi indicates support for the official integration API and API keys.
+	CapabilityIntegrationApi Capability = "integration-api"
+)
+
+// The minimum version of the UniFi Network application supporting each capability.
+var capabilityMinimumVersions = map[Capability]string{
+	CapabilityTrafficRules:      "7.2",
+	CapabilityTrafficRoutes:     "7.4",
+	CapabilityZoneBasedFirewall: "9.0",
+	CapabilityIntegrationApi:    "9.0",
+}
+
+// systemInfoResponse is the representation of a response of a system info request.
+type systemInfoResponse struct {
+	Meta Meta         `json:"meta"`
+	Data []SystemInfo `json:"data"`
+}
+
+// SystemInfo is the representation of the system information of the UniFi Network application.
+type SystemInfo struct {
+	// The version of the UniFi Network application e.g. "8.0.28".
+	Version string `json:"version,omitempty"`
+	// The build of the UniFi Network application e.g. "atag_8.0.28_24089".
+	Build string `json:"build,omitempty"`
+	// The version of the UniFi Network application before the last update.
+	PreviousVersion string `json:"previous_version,omitempty"`
+	// Indicates whether an update of the UniFi Network application is available.
+	UpdateAvailable bool `json:"update_available,omitempty"`
+	// The hostname of the UniFi controller.
+	Hostname string `json:"hostname,omitempty"`
+	// The name of the UniFi controller.
+	Name string `json:"name,omitempty"`
+	// The IP addresses of the UniFi controller.
+	IpAddrs []string `json:"ip_addrs,omitempty"`
+	// The timezone of the UniFi controller e.g. "Europe/Brussels".
+	Timezone string `json:"timezone,omitempty"`
+	// The uptime of the UniFi Network application in seconds.
+	Uptime int64 `json:"uptime,omitempty"`
+	// The port at which devices inform the UniFi controller.
+	InformPort int `json:"inform_port,omitempty"`
+	// The HTTPS port of the UniFi controller.
+	HttpsPort int `json:"https_port,omitempty"`
+	// The device type of the UniFi controller e.g. "UDMPRO" (only set on UniFi OS).
+	UbntDeviceType string `json:"ubnt_device_type,omitempty"`
+	// The UniFi OS version as displayed in the UI (only set on UniFi OS).
+	ConsoleDisplayVersion string `json:"console_display_version,omitempty"`
+	// The full UniFi OS version (only set on UniFi OS).
+	UdmVersion string `json:"udm_version,omitempty"`
+	// The capabilities supported by the version of the UniFi Network application.
+	Capabilities []Capability `json:"-"`
+}
+
+// unifiOsSystem is the representation of the response of the UniFi OS system endpoint
+// (`/api/system`), only the fields used to complete [SystemInfo] are included.
+type unifiOsSystem struct {
+	// The name of the console e.g. "Dream Machine Pro".
+	Name string `json:"name"`
+	// The hostname of the console.
+	Hostname string `json:"hostname"`
+	// The hardware of the console.
+	Hardware struct {
+		// The short name of the console model e.g. "UDMPRO".
+		Shortname string `json:"shortname"`
+	} `json:"hardware"`
+}
+
+// GetSystemInfo returns the system information of the UniFi Network application (`stat/sysinfo`)
+// including the capabilities supported by its version. On UniFi OS the fields sysinfo did not
+// include (the hostname, name and device type) are completed using the UniFi OS system endpoint
+// (`/api/system`) when possible. The version, build and uptime always come from sysinfo, as the
+// UniFi OS system endpoint describes the console and not the UniFi Network application.
+// It will return an error if it fails to fetch the system information.
+func (site *Site) GetSystemInfo() (SystemInfo, error) {
+	return site.GetSystemInfoWithContext(context.Background())
+}
+
+// GetSystemInfoWithContext is the same as [Site.GetSystemInfo] but uses the given context for the
+// request.
+func (site *Site) GetSystemInfoWithContext(ctx context.Context) (SystemInfo, error) {
+	endpointUrl := site.createEndpointUrl("stat/sysinfo", "")
+	responseData := systemInfoResponse{}
+
+	_, err := site.controller.execute(ctx, http.MethodGet, endpointUrl, nil, &responseData)
+	if err != nil {
+		return SystemInfo{}, fmt.Errorf("retreiving system info failed: %w", err)
+	}
+
+	if len(responseData.Data) == 0 {
+		return SystemInfo{}, errors.New("retreiving system info failed: empty response")
+	}
+
+	systemInfo := responseData.Data[0]
+	if site.controller.controllerType == ControllerTypeUnifiOs &&
+		(systemInfo.Hostname == "" || systemInfo.Name == "" || systemInfo.UbntDeviceType == "") {
+		site.completeSystemInfo(ctx, &systemInfo)
+	}
+
+	for capability, minimumVersion := range capabilityMinimumVersions {
+		if compareVersions(systemInfo.Version, minimumVersion) >= 0 {
+			systemInfo.Capabilities = append(systemInfo.Capabilities, capability)
+		}
+	}
+	slices.Sort(systemInfo.Capabilities)
+
+	return systemInfo, nil
+}
+
+// Completes the missing fields of the given system information using the UniFi OS system
+// endpoint. The endpoint is optional, so the system information is left unchanged if the request
+// fails.
+func (site *Site) completeSystemInfo(ctx context.Context, systemInfo *SystemInfo) {
+	endpointUrl := fmt.Sprintf("%s/api/system", site.controller.baseUrl)
+	responseData := unifiOsSystem{}
+
+	_, err := site.controller.execute(ctx, http.MethodGet, endpointUrl, nil, &responseData)
+	if err != nil {
+		return
+	}
+
+	if systemInfo.Hostname == "" {
+		systemInfo.Hostname = responseData.Hostname
+	}
+	if systemInfo.Name == "" {
+		systemInfo.Name = responseData.Name
+	}
+	if systemInfo.UbntDeviceType == "" {
+		systemInfo.UbntDeviceType = responseData.Hardware.Shortname
+	}
+}
+
+// Supports returns whether the given capability is supported by the UniFi Network application.
+func (systemInfo SystemInfo) Supports(capability Capability) bool {
+	return slices.Contains(systemInfo.Capabilities, capability)
+}
+
+// Require returns an error matching [NotSupportedError] (using [errors.Is]) describing the
+// required version if the given capability is not supported by the UniFi Network application.
+func (systemInfo SystemInfo) Require(capability Capability) error {
+	if systemInfo.Supports(capability) {
+		return nil
+	}
+
+	minimumVersion, ok := capabilityMinimumVersions[capability]
+	if !ok {
+		return fmt.Errorf("%w: unknown capability %q", NotSupportedError, string(capability))
+	}
+	return fmt.Errorf(
+		"%w: %s requires UniFi Network application %s or newer (controller runs %s)",
+		NotSupportedError,
+		capability,
+		minimumVersion,
+		systemInfo.Version,
+	)
+}
+
+// Compares the given dot separated versions numerically, a missing or non-numeric part is treated
+// as 0. It returns -1 if a is older than b, 1 if a is newer than b and 0 if they are equal.
+func compareVersions(a string, b string) int {
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		aPart := versionPart(aParts, i)
+		bPart := versionPart(bParts, i)
+		if aPart != bPart {
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// Returns the numeric value of the version part at the given index, ignoring any non-numeric
+// suffix (e.g. "28-beta" is 28). It returns 0 if the part is missing or not numeric.
+func versionPart(parts []string, index int) int {
+	if index >= len(parts) {
+		return 0
+	}
+
+	digits := parts[index]
+	end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' })
+	if end >= 0 {
+		digits = digits[:end]
+	}
+
+	value, err := strconv.Atoi(digits)
+	if err != nil {
+		return 0
+	}
+	return value
+}
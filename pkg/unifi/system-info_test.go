@@ -0,0 +1,132 @@
+package unifi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a        string
+		b        string
+		expected int
+	}{
+		{a: "8.0.7", b: "8.0.7", expected: 0},
+		{a: "8.0", b: "8.0.0", expected: 0},
+		{a: "8.0.7", b: "8.0.28", expected: -1},
+		{a: "8.0.28", b: "8.0.7", expected: 1},
+		{a: "9.0.108", b: "10.0.0", expected: -1},
+		{a: "10.0.0", b: "9.0.108", expected: 1},
+		{a: "8.1", b: "8.0.99", expected: 1},
+		{a: "8.0.28-beta", b: "8.0.28", expected: 0},
+		{a: "8.0.x", b: "8.0", expected: 0},
+		{a: "", b: "0.0.1", expected: -1},
+		{a: "", b: "", expected: 0},
+	}
+	for _, test := range tests {
+		actual := compareVersions(test.a, test.b)
+		if actual != test.expected {
+			t.Errorf("compareVersions(%q, %q) = %d, expected %d",
+				test.a, test.b, actual, test.expected)
+		}
+	}
+}
+
+// Builds a UniFi OS [Controller] authenticated using an API key for a server responding to the
+// sysinfo endpoint with the given system information and to the UniFi OS system endpoint with the
+// given body (404 if empty).
+func newSystemInfoTestController(
+	t *testing.T,
+	sysinfo string,
+	system string,
+) (*Controller, *atomic.Int32) {
+	t.Helper()
+
+	systemRequests := &atomic.Int32{}
+	mux := http.NewServeMux()
+	sysinfoPath := "/proxy/network/api/s/default/stat/sysinfo"
+	mux.HandleFunc(sysinfoPath, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[` + sysinfo + `]}`))
+	})
+	mux.HandleFunc("/api/system", func(w http.ResponseWriter, r *http.Request) {
+		systemRequests.Add(1)
+		if system == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(system))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	builder := ControllerBuilder{}
+	controller, err := builder.
+		SetBaseUrl(server.URL).
+		SetControllerType(ControllerTypeUnifiOs).
+		SetApiKey("api-key").
+		Build()
+	if err != nil {
+		t.Fatalf("building controller failed: %s", err)
+	}
+	return controller, systemRequests
+}
+
+func TestGetSystemInfoCompletesUnifiOsFields(t *testing.T) {
+	controller, systemRequests := newSystemInfoTestController(
+		t,
+		`{"version":"9.0.108","build":"atag_9.0.108","uptime":3600}`,
+		`{"name":"Dream Machine Pro","hostname":"udm","hardware":{"shortname":"UDMPRO"}}`,
+	)
+
+	systemInfo, err := controller.CreateDefaultSite().GetSystemInfo()
+	if err != nil {
+		t.Fatalf("retrieving system info failed: %s", err)
+	}
+	if systemInfo.Version != "9.0.108" || systemInfo.Uptime != 3600 {
+		t.Errorf("expected sysinfo version and uptime, got %+v", systemInfo)
+	}
+	if systemInfo.Hostname != "udm" ||
+		systemInfo.Name != "Dream Machine Pro" ||
+		systemInfo.UbntDeviceType != "UDMPRO" {
+		t.Errorf("expected fields completed by the system endpoint, got %+v", systemInfo)
+	}
+	if !systemInfo.Supports(CapabilityZoneBasedFirewall) {
+		t.Errorf("expected zone based firewall support, got %v", systemInfo.Capabilities)
+	}
+	if requests := systemRequests.Load(); requests != 1 {
+		t.Errorf("expected 1 system request, got %d", requests)
+	}
+}
+
+func TestGetSystemInfoPrefersSysinfoFields(t *testing.T) {
+	controller, systemRequests := newSystemInfoTestController(
+		t,
+		`{"version":"8.0.28","hostname":"unifi","name":"UniFi","ubnt_device_type":"UDMPRO"}`,
+		`{"name":"Other","hostname":"other","hardware":{"shortname":"OTHER"}}`,
+	)
+
+	systemInfo, err := controller.CreateDefaultSite().GetSystemInfo()
+	if err != nil {
+		t.Fatalf("retrieving system info failed: %s", err)
+	}
+	if systemInfo.Hostname != "unifi" || systemInfo.Name != "UniFi" {
+		t.Errorf("expected sysinfo fields, got %+v", systemInfo)
+	}
+	if requests := systemRequests.Load(); requests != 0 {
+		t.Errorf("expected no system request, got %d", requests)
+	}
+}
+
+func TestGetSystemInfoIgnoresMissingSystemEndpoint(t *testing.T) {
+	controller, _ := newSystemInfoTestController(t, `{"version":"8.0.28"}`, "")
+
+	systemInfo, err := controller.CreateDefaultSite().GetSystemInfo()
+	if err != nil {
+		t.Fatalf("retrieving system info failed: %s", err)
+	}
+	if systemInfo.Version != "8.0.28" || systemInfo.Hostname != "" {
+		t.Errorf("expected only sysinfo fields, got %+v", systemInfo)
+	}
+}
@@ -0,0 +1,48 @@
+package unifi
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The TOTP parameters used by UniFi two-factor authentication (RFC 6238 defaults).
+const (
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+	totpModulo = 1_000_000
+)
+
+// GenerateTotpCode generates the two-factor authentication code for the given time using the given
+// TOTP secret (base32 encoded, spaces and padding are ignored).
+// It returns an error if the secret is not valid base32.
+func GenerateTotpCode(totpSecret string, at time.Time) (string, error) {
+	secret := strings.ToUpper(strings.ReplaceAll(totpSecret, " ", ""))
+	secret = strings.TrimRight(secret, "=")
+	if secret == "" {
+		return "", errors.New("TOTP secret can not be empty")
+	}
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return "", errors.New("TOTP secret is not valid base32")
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(at.Unix()/int64(totpPeriod.Seconds())))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as described in RFC 4226.
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, code%totpModulo), nil
+}
@@ -0,0 +1,51 @@
+package unifi
+
+import (
+	"testing"
+	"time"
+)
+
+// The secret "12345678901234567890" of the RFC 6238 test vectors (base32 encoded).
+const rfcTotpSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTotpCode(t *testing.T) {
+	// The SHA-1 test vectors of RFC 6238 truncated to 6 digits.
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{unix: 59, code: "287082"},
+		{unix: 1111111109, code: "081804"},
+		{unix: 1111111111, code: "050471"},
+		{unix: 1234567890, code: "005924"},
+		{unix: 2000000000, code: "279037"},
+	}
+	for _, test := range tests {
+		code, err := GenerateTotpCode(rfcTotpSecret, time.Unix(test.unix, 0))
+		if err != nil {
+			t.Fatalf("generating code at %d failed: %s", test.unix, err)
+		}
+		if code != test.code {
+			t.Errorf("expected code %s at %d, got %s", test.code, test.unix, code)
+		}
+	}
+}
+
+func TestGenerateTotpCodeNormalizesSecret(t *testing.T) {
+	code, err := GenerateTotpCode("gezd gnbv gy3t qojq gezd gnbv gy3t qojq====", time.Unix(59, 0))
+	if err != nil {
+		t.Fatalf("generating code failed: %s", err)
+	}
+	if code != "287082" {
+		t.Errorf("expected code 287082, got %s", code)
+	}
+}
+
+func TestGenerateTotpCodeInvalidSecret(t *testing.T) {
+	for _, secret := range []string{"", "  ", "not base32!", "GEZDGNBV1"} {
+		_, err := GenerateTotpCode(secret, time.Unix(59, 0))
+		if err == nil {
+			t.Errorf("expected an error for secret %q", secret)
+		}
+	}
+}
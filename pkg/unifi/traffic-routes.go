@@ -0,0 +1,106 @@
+package unifi
+
+import "context"
+
+// The `trafficroutes` endpoint (v2 API) managing the traffic routes of a [Site].
+var trafficRouteResource = v2Resource[TrafficRoute]{
+	path:       "trafficroutes",
+	name:       "traffic route",
+	pluralName: "traffic routes",
+}
+
+// TrafficRoute is the representation of a traffic route (policy-based route), traffic routes
+// route the traffic of the target devices matching the MatchingTarget through a specific WAN or
+// VPN network.
+// Traffic routes require UniFi Network application 7.4 or newer, see [CapabilityTrafficRoutes].
+type TrafficRoute struct {
+	// The route ID.
+	Id string `json:"_id,omitempty"`
+	// The description of the route.
+	Description string `json:"description,omitempty"`
+	// Indicates whether the route is active.
+	Enabled bool `json:"enabled"`
+	// Determines which traffic is matched, options:
+	//	- INTERNET: All internet traffic.
+	//	- DOMAIN: The traffic to the domains in Domains.
+	//	- IP: The traffic to the IP addresses in IpAddresses and IpRanges.
+	//	- REGION: The traffic to the regions in Regions.
+	MatchingTarget string `json:"matching_target,omitempty"`
+	// The matched domains, used when MatchingTarget is `DOMAIN`.
+	Domains []TrafficDomain `json:"domains,omitempty"`
+	// The matched IP addresses and/or subnets, used when MatchingTarget is `IP`.
+	IpAddresses []TrafficIpAddress `json:"ip_addresses,omitempty"`
+	// The matched IP ranges, used when MatchingTarget is `IP`.
+	IpRanges []TrafficIpRange `json:"ip_ranges,omitempty"`
+	// The matched regions as ISO 3166-1 alpha-2 country codes e.g. "BE", used when MatchingTarget
+	// is `REGION`.
+	Regions []string `json:"regions,omitempty"`
+	// The devices whose traffic is matched.
+	TargetDevices []TrafficTargetDevice `json:"target_devices,omitempty"`
+	// The ID of the WAN or VPN network (see [Network]) through which the traffic is routed.
+	NetworkId string `json:"network_id,omitempty"`
+	// The IP address of the next hop, when empty the gateway of the network is used.
+	NextHop string `json:"next_hop,omitempty"`
+	// Indicates whether the matching traffic is blocked when the network is unavailable instead
+	// of being routed through the default route.
+	KillSwitchEnabled bool `json:"kill_switch_enabled"`
+}
+
+// CreateTrafficRoute creates a new traffic route linked to this [Site] using the given traffic
+// route data and returns the created traffic route.
+// It will return an error if the creation of the traffic route failed.
+func (site *Site) CreateTrafficRoute(trafficRoute TrafficRoute) (TrafficRoute, error) {
+	return site.CreateTrafficRouteWithContext(context.Background(), trafficRoute)
+}
+
+// CreateTrafficRouteWithContext is the same as [Site.CreateTrafficRoute] but uses the given
+// context for the request.
+func (site *Site) CreateTrafficRouteWithContext(
+	ctx context.Context,
+	trafficRoute TrafficRoute,
+) (TrafficRoute, error) {
+	return trafficRouteResource.create(ctx, site, trafficRoute)
+}
+
+// GetAllTrafficRoutes returns all traffic routes linked to this [Site].
+// It will return an error if it fails to fetch the traffic routes.
+func (site *Site) GetAllTrafficRoutes() ([]TrafficRoute, error) {
+	return site.GetAllTrafficRoutesWithContext(context.Background())
+}
+
+// GetAllTrafficRoutesWithContext is the same as [Site.GetAllTrafficRoutes] but uses the given
+// context for the request.
+func (site *Site) GetAllTrafficRoutesWithContext(ctx context.Context) ([]TrafficRoute, error) {
+	return trafficRouteResource.getAll(ctx, site)
+}
+
+// UpdateTrafficRoute updates the traffic route linked to the given ID and this [Site] using the
+// given traffic route data and returns the updated traffic route. The given data is merged over
+// the current traffic route, so its fields not included in [TrafficRoute] are preserved.
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no traffic route has
+// the given ID or an error if the update of the traffic route failed.
+func (site *Site) UpdateTrafficRoute(id string, trafficRoute TrafficRoute) (TrafficRoute, error) {
+	return site.UpdateTrafficRouteWithContext(context.Background(), id, trafficRoute)
+}
+
+// UpdateTrafficRouteWithContext is the same as [Site.UpdateTrafficRoute] but uses the given
+// context for the request.
+func (site *Site) UpdateTrafficRouteWithContext(
+	ctx context.Context,
+	id string,
+	trafficRoute TrafficRoute,
+) (TrafficRoute, error) {
+	return trafficRouteResource.update(ctx, site, id, trafficRoute)
+}
+
+// DeleteTrafficRoute deletes the traffic route linked to the given ID and this [Site].
+// It will return an error if the deletion of the traffic route failed.
+func (site *Site) DeleteTrafficRoute(id string) error {
+	return site.DeleteTrafficRouteWithContext(context.Background(), id)
+}
+
+// DeleteTrafficRouteWithContext is the same as [Site.DeleteTrafficRoute] but uses the given
+// context for the request.
+func (site *Site) DeleteTrafficRouteWithContext(ctx context.Context, id string) error {
+	return trafficRouteResource.delete(ctx, site, id)
+}
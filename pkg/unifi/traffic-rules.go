@@ -0,0 +1,208 @@
+package unifi
+
+import "context"
+
+// The `trafficrules` endpoint (v2 API) managing the traffic rules of a [Site].
+var trafficRuleResource = v2Resource[TrafficRule]{
+	path:       "trafficrules",
+	name:       "traffic rule",
+	pluralName: "traffic rules",
+}
+
+// TrafficRule is the representation of a traffic rule, traffic rules block, allow or limit the
+// traffic of the target devices matching the MatchingTarget.
+// Traffic rules require UniFi Network application 7.2 or newer, see [CapabilityTrafficRules].
+type TrafficRule struct {
+	// The rule ID.
+	Id string `json:"_id,omitempty"`
+	// The description of the rule.
+	Description string `json:"description,omitempty"`
+	// Indicates whether the rule is active.
+	Enabled bool `json:"enabled"`
+	// What action the rule should take, options:
+	//	- BLOCK: The matching traffic is blocked.
+	//	- ALLOW: The matching traffic is allowed.
+	Action string `json:"action,omitempty"`
+	// Determines which traffic is matched, options:
+	//	- INTERNET: All internet traffic.
+	//	- APP: The traffic of the applications in AppIds.
+	//	- APP_CATEGORY: The traffic of the application categories in AppCategoryIds.
+	//	- DOMAIN: The traffic to the domains in Domains.
+	//	- IP: The traffic to the IP addresses in IpAddresses and IpRanges.
+	//	- REGION: The traffic to the regions in Regions.
+	//	- LOCAL_NETWORK: The traffic to the networks in NetworkIds.
+	MatchingTarget string `json:"matching_target,omitempty"`
+	// The IDs of the matched applications, used when MatchingTarget is `APP`.
+	AppIds []int `json:"app_ids,omitempty"`
+	// The IDs of the matched application categories, used when MatchingTarget is `APP_CATEGORY`.
+	AppCategoryIds []int `json:"app_category_ids,omitempty"`
+	// The matched domains, used when MatchingTarget is `DOMAIN`.
+	Domains []TrafficDomain `json:"domains,omitempty"`
+	// The matched IP addresses and/or subnets, used when MatchingTarget is `IP`.
+	IpAddresses []TrafficIpAddress `json:"ip_addresses,omitempty"`
+	// The matched IP ranges, used when MatchingTarget is `IP`.
+	IpRanges []TrafficIpRange `json:"ip_ranges,omitempty"`
+	// The matched regions as ISO 3166-1 alpha-2 country codes e.g. "BE", used when MatchingTarget
+	// is `REGION`.
+	Regions []string `json:"regions,omitempty"`
+	// The IDs of the matched networks (see [Network]), used when MatchingTarget is
+	// `LOCAL_NETWORK`.
+	NetworkIds []string `json:"network_ids,omitempty"`
+	// The devices whose traffic is matched.
+	TargetDevices []TrafficTargetDevice `json:"target_devices,omitempty"`
+	// The schedule during which the rule is active.
+	Schedule *TrafficSchedule `json:"schedule,omitempty"`
+	// The bandwidth limit applied to the matching traffic.
+	BandwidthLimit *TrafficBandwidthLimit `json:"bandwidth_limit,omitempty"`
+}
+
+// TrafficDomain is the representation of a domain matched by a [TrafficRule] or [TrafficRoute].
+type TrafficDomain struct {
+	// The domain e.g. "example.com".
+	Domain string `json:"domain,omitempty"`
+	// The matched ports, all ports are matched if both Ports and PortRanges are empty.
+	Ports []int `json:"ports,omitempty"`
+	// The matched port ranges, all ports are matched if both Ports and PortRanges are empty.
+	PortRanges []TrafficPortRange `json:"port_ranges,omitempty"`
+}
+
+// TrafficIpAddress is the representation of an IP address or subnet matched by a [TrafficRule] or
+// [TrafficRoute].
+type TrafficIpAddress struct {
+	// The IP address or subnet in CIDR notation e.g. "203.0.113.0/24".
+	IpOrSubnet string `json:"ip_or_subnet,omitempty"`
+	// The IP version, options: v4, v6.
+	IpVersion string `json:"ip_version,omitempty"`
+	// The matched ports, all ports are matched if both Ports and PortRanges are empty.
+	Ports []int `json:"ports,omitempty"`
+	// The matched port ranges, all ports are matched if both Ports and PortRanges are empty.
+	PortRanges []TrafficPortRange `json:"port_ranges,omitempty"`
+}
+
+// TrafficIpRange is the representation of an IP range matched by a [TrafficRule] or
+// [TrafficRoute].
+type TrafficIpRange struct {
+	// The first IP address of the range.
+	IpStart string `json:"ip_start,omitempty"`
+	// The last IP address of the range.
+	IpStop string `json:"ip_stop,omitempty"`
+	// The IP version, options: v4, v6.
+	IpVersion string `json:"ip_version,omitempty"`
+}
+
+// TrafficPortRange is the representation of a port range matched by a [TrafficRule] or
+// [TrafficRoute].
+type TrafficPortRange struct {
+	// The first port of the range.
+	PortStart int `json:"port_start,omitempty"`
+	// The last port of the range.
+	PortStop int `json:"port_stop,omitempty"`
+}
+
+// TrafficTargetDevice is the representation of the device(s) whose traffic is matched by a
+// [TrafficRule] or [TrafficRoute].
+type TrafficTargetDevice struct {
+	// The type of target, options:
+	//	- ALL_CLIENTS: All clients.
+	//	- CLIENT: The client with the MAC address ClientMac.
+	//	- NETWORK: The clients of the network with the ID NetworkId.
+	Type string `json:"type,omitempty"`
+	// The MAC address of the client, used when Type is `CLIENT`.
+	ClientMac string `json:"client_mac,omitempty"`
+	// The ID of the network (see [Network]), used when Type is `NETWORK`.
+	NetworkId string `json:"network_id,omitempty"`
+}
+
+// TrafficSchedule is the representation of the schedule during which a [TrafficRule] or
+// [FirewallPolicy] is active.
+type TrafficSchedule struct {
+	// The schedule mode, options:
+	//	- ALWAYS: The rule is always active.
+	//	- EVERY_DAY: The rule is active every day during the time range.
+	//	- EVERY_WEEK: The rule is active on the RepeatOnDays during the time range.
+	//	- ONE_TIME_ONLY: The rule is active between DateStart and DateEnd during the time range.
+	//	- CUSTOM: The rule is active on the RepeatOnDays between DateStart and DateEnd during the
+	//		time range.
+	Mode string `json:"mode,omitempty"`
+	// The days of the week on which the rule is active e.g. "mon", "tue".
+	RepeatOnDays []string `json:"repeat_on_days,omitempty"`
+	// Indicates whether the rule is active all day instead of during the time range.
+	TimeAllDay bool `json:"time_all_day"`
+	// The start of the time range e.g. "09:00".
+	TimeRangeStart string `json:"time_range_start,omitempty"`
+	// The end of the time range e.g. "17:00".
+	TimeRangeEnd string `json:"time_range_end,omitempty"`
+	// The first date on which the rule is active e.g. "2024-01-31".
+	DateStart string `json:"date_start,omitempty"`
+	// The last date on which the rule is active e.g. "2024-02-29".
+	DateEnd string `json:"date_end,omitempty"`
+}
+
+// TrafficBandwidthLimit is the representation of the bandwidth limit applied by a [TrafficRule].
+type TrafficBandwidthLimit struct {
+	// Indicates whether the bandwidth limit is applied.
+	Enabled bool `json:"enabled"`
+	// The download limit in kbps.
+	DownloadLimitKbps int `json:"download_limit_kbps,omitempty"`
+	// The upload limit in kbps.
+	UploadLimitKbps int `json:"upload_limit_kbps,omitempty"`
+}
+
+// CreateTrafficRule creates a new traffic rule linked to this [Site] using the given traffic rule
+// data and returns the created traffic rule.
+// It will return an error if the creation of the traffic rule failed.
+func (site *Site) CreateTrafficRule(trafficRule TrafficRule) (TrafficRule, error) {
+	return site.CreateTrafficRuleWithContext(context.Background(), trafficRule)
+}
+
+// CreateTrafficRuleWithContext is the same as [Site.CreateTrafficRule] but uses the given context
+// for the request.
+func (site *Site) CreateTrafficRuleWithContext(
+	ctx context.Context,
+	trafficRule TrafficRule,
+) (TrafficRule, error) {
+	return trafficRuleResource.create(ctx, site, trafficRule)
+}
+
+// GetAllTrafficRules returns all traffic rules linked to this [Site].
+// It will return an error if it fails to fetch the traffic rules.
+func (site *Site) GetAllTrafficRules() ([]TrafficRule, error) {
+	return site.GetAllTrafficRulesWithContext(context.Background())
+}
+
+// GetAllTrafficRulesWithContext is the same as [Site.GetAllTrafficRules] but uses the given
+// context for the request.
+func (site *Site) GetAllTrafficRulesWithContext(ctx context.Context) ([]TrafficRule, error) {
+	return trafficRuleResource.getAll(ctx, site)
+}
+
+// UpdateTrafficRule updates the traffic rule linked to the given ID and this [Site] using the
+// given traffic rule data and returns the updated traffic rule. The given data is merged over the
+// current traffic rule, so its fields not included in [TrafficRule] are preserved.
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no traffic rule has the
+// given ID or an error if the update of the traffic rule failed.
+func (site *Site) UpdateTrafficRule(id string, trafficRule TrafficRule) (TrafficRule, error) {
+	return site.UpdateTrafficRuleWithContext(context.Background(), id, trafficRule)
+}
+
+// UpdateTrafficRuleWithContext is the same as [Site.UpdateTrafficRule] but uses the given context
+// for the request.
+func (site *Site) UpdateTrafficRuleWithContext(
+	ctx context.Context,
+	id string,
+	trafficRule TrafficRule,
+) (TrafficRule, error) {
+	return trafficRuleResource.update(ctx, site, id, trafficRule)
+}
+
+// DeleteTrafficRule deletes the traffic rule linked to the given ID and this [Site].
+// It will return an error if the deletion of the traffic rule failed.
+func (site *Site) DeleteTrafficRule(id string) error {
+	return site.DeleteTrafficRuleWithContext(context.Background(), id)
+}
+
+// DeleteTrafficRuleWithContext is the same as [Site.DeleteTrafficRule] but uses the given context
+// for the request.
+func (site *Site) DeleteTrafficRuleWithContext(ctx context.Context, id string) error {
+	return trafficRuleResource.delete(ctx, site, id)
+}
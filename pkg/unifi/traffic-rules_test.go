@@ -0,0 +1,56 @@
+package unifi
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUpdateTrafficRulePreservesUnknownFields(t *testing.T) {
+	store := &v2TestStore{objects: []map[string]any{
+		{
+			"_id":         "rule",
+			"description": "Old",
+			"enabled":     true,
+			"action":      "BLOCK",
+			"schedule":    map[string]any{"mode": "ALWAYS"},
+			"new_field":   "kept",
+		},
+	}}
+	server := newTestServer(t)
+	server.handler = store.handler(t, "trafficrules")
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	trafficRule, err := site.UpdateTrafficRule("rule", TrafficRule{Description: "New"})
+	if err != nil {
+		t.Fatalf("updating traffic rule failed: %s", err)
+	}
+	if trafficRule.Description != "New" {
+		t.Errorf("expected updated description New, got %s", trafficRule.Description)
+	}
+
+	object := store.object("rule")
+	if object["description"] != "New" || object["enabled"] != false {
+		t.Errorf("expected typed fields to be updated, got %v", object)
+	}
+	if object["action"] != "BLOCK" || object["new_field"] != "kept" {
+		t.Errorf("expected other fields to be preserved, got %v", object)
+	}
+	if schedule, ok := object["schedule"].(map[string]any); !ok || schedule["mode"] != "ALWAYS" {
+		t.Errorf("expected schedule to be preserved, got %v", object["schedule"])
+	}
+}
+
+func TestUpdateTrafficRouteUnknownId(t *testing.T) {
+	store := &v2TestStore{objects: []map[string]any{{"_id": "route"}}}
+	server := newTestServer(t)
+	server.handler = store.handler(t, "trafficroutes")
+	site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+	_, err := site.UpdateTrafficRoute("unknown", TrafficRoute{Description: "New"})
+	if !errors.Is(err, NotFoundError) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if len(store.updates) != 0 {
+		t.Errorf("expected no updates, got %v", store.updates)
+	}
+}
@@ -0,0 +1,168 @@
+package unifi
+
+import "context"
+
+// UserGroupResponse is the representation of a response of a user group request.
+type UserGroupResponse struct {
+	Meta Meta                    `json:"meta"`
+	Data []UserGroupResponseData `json:"data"`
+}
+
+// UserGroupResponseData is the representation of the data inside the data array of the
+// [UserGroupResponse]. It contains either [UserGroup] or [DataValidationError] based on whether
+// the request succeeded.
+type UserGroupResponseData struct {
+	*UserGroup
+	*DataValidationError
+}
+
+// The `rest/usergroup` endpoint managing the user groups of a [Site].
+var userGroupResource = restResource[UserGroup, UserGroupResponse]{
+	path:       "rest/usergroup",
+	name:       "user group",
+	pluralName: "user groups",
+}
+
+// UserGroup is the representation of a user group (bandwidth profile), the bandwidth limits of a
+// user group apply to each client linked to it (see [Site.SetClientUserGroup]).
+type UserGroup struct {
+	// The user group ID.
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this user group.
+	SiteId string `json:"site_id,omitempty"`
+	// The name of the user group.
+	Name string `json:"name,omitempty"`
+	// The download limit of a client in kbps, -1 for no limit.
+	QosRateMaxDown int `json:"qos_rate_max_down,omitempty"`
+	// The upload limit of a client in kbps, -1 for no limit.
+	QosRateMaxUp int `json:"qos_rate_max_up,omitempty"`
+	// Indicates whether the user group can not be deleted (e.g. the default user group).
+	AttrNoDelete bool `json:"attr_no_delete,omitempty"`
+	// The ID of the hidden attributes of the user group e.g. "default" for the default user group.
+	AttrHiddenId string `json:"attr_hidden_id,omitempty"`
+}
+
+// CreateUserGroup creates a new user group linked to this [Site] using the given user group data.
+// It will return an error if the creation of the user group failed.
+func (site *Site) CreateUserGroup(userGroup UserGroup) (UserGroupResponse, error) {
+	return site.CreateUserGroupWithContext(context.Background(), userGroup)
+}
+
+// CreateUserGroupWithContext is the same as [Site.CreateUserGroup] but uses the given context for
+// the request.
+func (site *Site) CreateUserGroupWithContext(
+	ctx context.Context,
+	userGroup UserGroup,
+) (UserGroupResponse, error) {
+	return userGroupResource.create(ctx, site, userGroup)
+}
+
+// GetAllUserGroups returns all user groups linked to this [Site].
+// It will return an error if it fails to fetch the user groups.
+func (site *Site) GetAllUserGroups() (UserGroupResponse, error) {
+	return site.GetAllUserGroupsWithContext(context.Background())
+}
+
+// GetAllUserGroupsWithContext is the same as [Site.GetAllUserGroups] but uses the given context
+// for the request.
+func (site *Site) GetAllUserGroupsWithContext(ctx context.Context) (UserGroupResponse, error) {
+	return userGroupResource.getAll(ctx, site)
+}
+
+// GetUserGroup returns the user group linked to the given ID and this [Site].
+// It will return an error if it fails to fetch the specific user group, however if no user group
+// with the given ID is present or the ID is invalid no error but a response with an empty data
+// array will be returned.
+func (site *Site) GetUserGroup(id string) (UserGroupResponse, error) {
+	return site.GetUserGroupWithContext(context.Background(), id)
+}
+
+// GetUserGroupWithContext is the same as [Site.GetUserGroup] but uses the given context for the
+// request.
+func (site *Site) GetUserGroupWithContext(
+	ctx context.Context,
+	id string,
+) (UserGroupResponse, error) {
+	return userGroupResource.get(ctx, site, id)
+}
+
+// UpdateUserGroup updates the user group linked to the given ID and this [Site] using the given
+// user group data. It will return an error if the update of the user group failed.
+func (site *Site) UpdateUserGroup(id string, userGroup UserGroup) (UserGroupResponse, error) {
+	return site.UpdateUserGroupWithContext(context.Background(), id, userGroup)
+}
+
+// UpdateUserGroupWithContext is the same as [Site.UpdateUserGroup] but uses the given context for
+// the request.
+func (site *Site) UpdateUserGroupWithContext(
+	ctx context.Context,
+	id string,
+	userGroup UserGroup,
+) (UserGroupResponse, error) {
+	return userGroupResource.update(ctx, site, id, userGroup)
+}
+
+// DeleteUserGroup deletes the user group linked to the given ID and this [Site], the clients
+// linked to the user group should be moved to another user group first (see
+// [Site.MoveClientsToUserGroup]). It will return an error if the deletion of the user group
+// failed.
+func (site *Site) DeleteUserGroup(id string) (UserGroupResponse, error) {
+	return site.DeleteUserGroupWithContext(context.Background(), id)
+}
+
+// DeleteUserGroupWithContext is the same as [Site.DeleteUserGroup] but uses the given context for
+// the request.
+func (site *Site) DeleteUserGroupWithContext(
+	ctx context.Context,
+	id string,
+) (UserGroupResponse, error) {
+	return userGroupResource.delete(ctx, site, id)
+}
+
+// MoveClientsToUserGroup links all known clients of this [Site] linked to the user group with the
+// given source ID to the user group with the given destination ID and returns the number of moved
+// clients. Clients without a user group (using the default user group) are moved when the source
+// ID is empty. No requests are sent when the source and destination ID are equal.
+// It will return an error if it fails to fetch the clients or the update of a client failed, the
+// clients updated before the failure remain moved.
+func (site *Site) MoveClientsToUserGroup(
+	sourceUserGroupId string,
+	destinationUserGroupId string,
+) (int, error) {
+	return site.MoveClientsToUserGroupWithContext(
+		context.Background(),
+		sourceUserGroupId,
+		destinationUserGroupId,
+	)
+}
+
+// MoveClientsToUserGroupWithContext is the same as [Site.MoveClientsToUserGroup] but uses the
+// given context for the requests.
+func (site *Site) MoveClientsToUserGroupWithContext(
+	ctx context.Context,
+	sourceUserGroupId string,
+	destinationUserGroupId string,
+) (int, error) {
+	if sourceUserGroupId == destinationUserGroupId {
+		return 0, nil
+	}
+
+	response, err := site.GetAllKnownClientsWithContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	for _, responseData := range response.Data {
+		if responseData.Client == nil || responseData.UserGroupId != sourceUserGroupId {
+			continue
+		}
+
+		_, err = site.SetClientUserGroupWithContext(ctx, responseData.Id, destinationUserGroupId)
+		if err != nil {
+			return moved, err
+		}
+		moved++
+	}
+	return moved, nil
+}
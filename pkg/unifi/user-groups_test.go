@@ -0,0 +1,70 @@
+package unifi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMoveClientsToUserGroup(t *testing.T) {
+	tests := []struct {
+		name        string
+		source      string
+		destination string
+		moved       []string
+	}{
+		{name: "user group", source: "group-a", destination: "group-b", moved: []string{"1"}},
+		{name: "default user group", source: "", destination: "group-b", moved: []string{"2", "4"}},
+		{name: "same user group", source: "group-a", destination: "group-a"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newTestServer(t)
+			var mutex sync.Mutex
+			var moved []string
+			server.handler = func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/api/s/default/rest/user":
+					_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[` +
+						`{"_id":"1","usergroup_id":"group-a"},` +
+						`{"_id":"2"},` +
+						`{"_id":"3","usergroup_id":"group-b"},` +
+						`{"_id":"4","usergroup_id":""}]}`))
+				case r.Method == http.MethodPut &&
+					strings.HasPrefix(r.URL.Path, "/api/s/default/rest/user/"):
+					byteArray, _ := io.ReadAll(r.Body)
+					var body map[string]any
+					if err := json.Unmarshal(byteArray, &body); err != nil {
+						t.Errorf("parsing request body %q failed: %s", byteArray, err)
+					}
+					if body["usergroup_id"] != test.destination {
+						t.Errorf("expected user group %s, got %v", test.destination, body)
+					}
+					id := strings.TrimPrefix(r.URL.Path, "/api/s/default/rest/user/")
+					mutex.Lock()
+					moved = append(moved, id)
+					mutex.Unlock()
+					_, _ = w.Write([]byte(`{"meta":{"rc":"ok"},"data":[]}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}
+			site := newAuthenticatedTestController(t, server).CreateDefaultSite()
+
+			count, err := site.MoveClientsToUserGroup(test.source, test.destination)
+			if err != nil {
+				t.Fatalf("moving clients failed: %s", err)
+			}
+			if count != len(test.moved) || !slices.Equal(moved, test.moved) {
+				t.Errorf("expected clients %v to be moved, got %v (%d)", test.moved, moved, count)
+			}
+			if len(test.moved) == 0 && server.requests.Load() != 0 {
+				t.Errorf("expected no requests, got %d", server.requests.Load())
+			}
+		})
+	}
+}
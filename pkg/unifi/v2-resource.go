@@ -0,0 +1,210 @@
+package unifi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// A v2Resource describes an endpoint of the v2 API of a [Site] (e.g. `trafficrules`), which
+// manages objects of type T. Unlike the `rest/` endpoints (see restResource) the v2 API does not
+// wrap its responses in a `{meta, data}` envelope but returns the (array of) object(s) directly.
+type v2Resource[T any] struct {
+	// The endpoint path e.g. "trafficrules".
+	path string
+	// The name of the object used in error messages e.g. "traffic rule".
+	name string
+	// The plural name of the object used in error messages e.g. "traffic rules".
+	pluralName string
+}
+
+// rawObject is an object of type T together with all of its fields as raw JSON, including the
+// fields not included in T. Updating an object using its raw fields preserves these fields.
+type rawObject[T any] struct {
+	// The parsed object.
+	object T
+	// All fields of the object as raw JSON.
+	fields map[string]json.RawMessage
+}
+
+// Replaces the field with the given name by the JSON representation of the given value.
+// It returns an error if the value could not be transformed to JSON.
+func (object rawObject[T]) set(name string, value any) error {
+	byteArray, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	object.fields[name] = byteArray
+	return nil
+}
+
+// Creates a new object linked to the given [Site] using the given object data and returns the
+// created object. It will return an error if the creation of the object failed.
+func (resource v2Resource[T]) create(ctx context.Context, site *Site, object T) (T, error) {
+	var responseData T
+	err := resource.execute(
+		ctx,
+		site,
+		http.MethodPost,
+		"",
+		object,
+		&responseData,
+		fmt.Sprintf("creating %s", resource.name),
+	)
+	return responseData, err
+}
+
+// Returns all objects linked to the given [Site].
+// It will return an error if it fails to fetch the objects.
+func (resource v2Resource[T]) getAll(ctx context.Context, site *Site) ([]T, error) {
+	var responseData []T
+	err := resource.execute(
+		ctx,
+		site,
+		http.MethodGet,
+		"",
+		nil,
+		&responseData,
+		fmt.Sprintf("retreiving %s", resource.pluralName),
+	)
+	return responseData, err
+}
+
+// Returns all objects linked to the given [Site] together with their raw fields.
+// It will return an error if it fails to fetch or parse the objects.
+func (resource v2Resource[T]) getAllRaw(ctx context.Context, site *Site) ([]rawObject[T], error) {
+	operation := fmt.Sprintf("retreiving %s", resource.pluralName)
+	var responseData []json.RawMessage
+	err := resource.execute(ctx, site, http.MethodGet, "", nil, &responseData, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]rawObject[T], 0, len(responseData))
+	for _, data := range responseData {
+		object := rawObject[T]{}
+		err = json.Unmarshal(data, &object.object)
+		if err == nil {
+			err = json.Unmarshal(data, &object.fields)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s failed: %w", operation, err)
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+// Updates the object linked to the given ID and [Site] using the given raw fields (see rawObject)
+// and returns the updated object. It will return an error if the update of the object failed.
+func (resource v2Resource[T]) updateRaw(
+	ctx context.Context,
+	site *Site,
+	id string,
+	fields map[string]json.RawMessage,
+) (T, error) {
+	var responseData T
+	err := resource.execute(
+		ctx,
+		site,
+		http.MethodPut,
+		id,
+		fields,
+		&responseData,
+		fmt.Sprintf("%s update", resource.name),
+	)
+	return responseData, err
+}
+
+// Updates the object linked to the given ID and [Site] using the given object data and returns
+// the updated object. As the v2 API replaces the whole object, the current object is fetched and
+// the fields of the given object data are merged over its fields, so fields not included in T (or
+// omitted by its JSON representation) keep their current value.
+// It will return an error matching [NotFoundError] (using [errors.Is]) if no object has the given
+// ID or an error if the update of the object failed.
+func (resource v2Resource[T]) update(
+	ctx context.Context,
+	site *Site,
+	id string,
+	object T,
+) (T, error) {
+	var updated T
+	operation := fmt.Sprintf("%s update", resource.name)
+
+	byteArray, err := json.Marshal(object)
+	if err != nil {
+		return updated, fmt.Errorf("%s failed: %w", operation, err)
+	}
+	var changedFields map[string]json.RawMessage
+	err = json.Unmarshal(byteArray, &changedFields)
+	if err != nil {
+		return updated, fmt.Errorf("%s failed: %w", operation, err)
+	}
+
+	objects, err := resource.getAllRaw(ctx, site)
+	if err != nil {
+		return updated, err
+	}
+
+	for _, current := range objects {
+		if !rawStringEquals(current.fields["_id"], id) {
+			continue
+		}
+
+		for name, value := range changedFields {
+			current.fields[name] = value
+		}
+		return resource.updateRaw(ctx, site, id, current.fields)
+	}
+	return updated, fmt.Errorf(
+		"%s failed: %w: no %s with ID %q",
+		operation,
+		NotFoundError,
+		resource.name,
+		id,
+	)
+}
+
+// Returns whether the given raw JSON value is the given string.
+func rawStringEquals(value json.RawMessage, expected string) bool {
+	var actual string
+	return json.Unmarshal(value, &actual) == nil && actual == expected
+}
+
+// Deletes the object linked to the given ID and [Site].
+// It will return an error if the deletion of the object failed.
+func (resource v2Resource[T]) delete(ctx context.Context, site *Site, id string) error {
+	// The response of a deletion has no (or an empty) body, so it is not parsed.
+	return resource.execute(
+		ctx,
+		site,
+		http.MethodDelete,
+		id,
+		nil,
+		nil,
+		fmt.Sprintf("deleting %s", resource.name),
+	)
+}
+
+// Executes a request with the given method and body to the v2 endpoint of the given [Site] and ID
+// (if not empty). If responseData is set the response body will be parsed and stored in this
+// variable. It will return an error describing the given operation if the request fails.
+func (resource v2Resource[T]) execute(
+	ctx context.Context,
+	site *Site,
+	method string,
+	id string,
+	body any,
+	responseData any,
+	operation string,
+) error {
+	endpointUrl := site.createV2EndpointUrl(resource.path, id)
+
+	_, err := site.controller.execute(ctx, method, endpointUrl, body, responseData)
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", operation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package unifi
+
+import "context"
+
+// WlanResponse is the representation of a response of a WLAN request.
+type WlanResponse struct {
+	Meta Meta               `json:"meta"`
+	Data []WlanResponseData `json:"data"`
+}
+
+// WlanResponseData is the representation of the data inside the data array of the
+// [WlanResponse]. It contains either [Wlan] or [DataValidationError] based on whether the request
+// succeeded.
+type WlanResponseData struct {
+	*Wlan
+	*DataValidationError
+}
+
+// The `rest/wlanconf` endpoint managing the WLANs of a [Site].
+var wlanResource = restResource[Wlan, WlanResponse]{
+	path:       "rest/wlanconf",
+	name:       "WLAN",
+	pluralName: "WLANs",
+}
+
+// Wlan is the representation of a WLAN (wireless network).
+type Wlan struct {
+	// The WLAN ID.
+	Id string `json:"_id,omitempty"`
+	// The ID of the site linked to this WLAN.
+	SiteId string `json:"site_id,omitempty"`
+	// The SSID of the WLAN.
+	Name string `json:"name,omitempty"`
+	// Indicates whether the WLAN is active, use [Site.EnableWlan] and [Site.DisableWlan] to change
+	// it as false is omitted.
+	Enabled bool `json:"enabled,omitempty"`
+	// The security of the WLAN, options:
+	//	- open: No authentication.
+	//	- wpapsk: WPA personal, authentication using Passphrase.
+	//	- wpaeap: WPA enterprise, authentication using a RADIUS server (see RadiusProfileId).
+	//	- wep: WEP (deprecated).
+	Security string `json:"security,omitempty"`
+	// The WPA mode, used when Security is `wpapsk` or `wpaeap`, options:
+	//	- auto: WPA and WPA2.
+	//	- wpa1: WPA only.
+	//	- wpa2: WPA2 only.
+	WpaMode string `json:"wpa_mode,omitempty"`
+	// The WPA encryption, options: ccmp, gcmp, auto.
+	WpaEnc string `json:"wpa_enc,omitempty"`
+	// Indicates whether WPA3 is supported.
+	Wpa3Support bool `json:"wpa3_support,omitempty"`
+	// Indicates whether WPA2 clients are still allowed when WPA3 is supported (WPA2/WPA3 mixed).
+	Wpa3Transition bool `json:"wpa3_transition,omitempty"`
+	// The protected management frames mode, options: disabled, optional, required.
+	PmfMode string `json:"pmf_mode,omitempty"`
+	// The passphrase of the WLAN (8-63 characters), used when Security is `wpapsk`.
+	Passphrase string `json:"x_passphrase,omitempty"`
+	// The ID of the RADIUS profile, used when Security is `wpaeap`.
+	RadiusProfileId string `json:"radiusprofile_id,omitempty"`
+	// The ID of the network (see [Network]) the clients of the WLAN are connected to.
+	NetworkConfId string `json:"networkconf_id,omitempty"`
+	// The ID of the user group applying the bandwidth limits of the clients of the WLAN.
+	UserGroupId string `json:"usergroup_id,omitempty"`
+	// The IDs of the AP groups broadcasting the WLAN.
+	ApGroupIds []string `json:"ap_group_ids,omitempty"`
+	// The bands on which the WLAN is broadcast, options: 2g, 5g, 6g.
+	WlanBands []string `json:"wlan_bands,omitempty"`
+	// The band on which the WLAN is broadcast (older controllers), options: both, 2g, 5g.
+	WlanBand string `json:"wlan_band,omitempty"`
+	// Band steering, indicates whether clients supporting 5GHz (based on their OUI) are prevented
+	// from connecting to 2.4GHz.
+	No2GhzOui bool `json:"no2ghz_oui,omitempty"`
+	// Indicates whether BSS transition (802.11v) is enabled, this allows steering clients to a
+	// better access point.
+	BssTransition bool `json:"bss_transition,omitempty"`
+	// Indicates whether fast roaming (802.11r) is enabled.
+	FastRoamingEnabled bool `json:"fast_roaming_enabled,omitempty"`
+	// Indicates whether the SSID is hidden (not broadcast).
+	HideSsid bool `json:"hide_ssid,omitempty"`
+	// Indicates whether the clients of the WLAN are isolated from each other.
+	L2Isolation bool `json:"l2_isolation,omitempty"`
+	// Indicates whether the guest policies (e.g. the hotspot portal) apply to the WLAN.
+	IsGuest bool `json:"is_guest,omitempty"`
+	// Indicates whether the WLAN is only broadcast during the schedule (see ScheduleWithDuration).
+	ScheduleEnabled bool `json:"schedule_enabled,omitempty"`
+	// The schedule during which the WLAN is broadcast.
+	ScheduleWithDuration []WlanSchedule `json:"schedule_with_duration,omitempty"`
+	// Indicates whether the MAC filter (MacFilterList) is enabled.
+	MacFilterEnabled bool `json:"mac_filter_enabled,omitempty"`
+	// The MAC addresses of the MAC filter.
+	MacFilterList []string `json:"mac_filter_list,omitempty"`
+	// The policy of the MAC filter, options:
+	//	- allow: Only the MAC addresses in the MacFilterList can connect.
+	//	- deny: The MAC addresses in the MacFilterList can not connect.
+	MacFilterPolicy string `json:"mac_filter_policy,omitempty"`
+}
+
+// WlanSchedule is the representation of a period during which a [Wlan] is broadcast.
+type WlanSchedule struct {
+	// The name of the period.
+	Name string `json:"name,omitempty"`
+	// The days of the week at which the period starts e.g. "mon", "tue".
+	StartDaysOfWeek []string `json:"start_days_of_week,omitempty"`
+	// The hour (0-23) at which the period starts.
+	StartHour int `json:"start_hour"`
+	// The minute (0-59) at which the period starts.
+	StartMinute int `json:"start_minute"`
+	// The duration of the period in minutes.
+	DurationMinutes int `json:"duration_minutes,omitempty"`
+}
+
+// CreateWlan creates a new WLAN linked to this [Site] using the given WLAN data.
+// It will return an error if the creation of the WLAN failed.
+func (site *Site) CreateWlan(wlan Wlan) (WlanResponse, error) {
+	return site.CreateWlanWithContext(context.Background(), wlan)
+}
+
+// CreateWlanWithContext is the same as [Site.CreateWlan] but uses the given context for the
+// request.
+func (site *Site) CreateWlanWithContext(ctx context.Context, wlan Wlan) (WlanResponse, error) {
+	return wlanResource.create(ctx, site, wlan)
+}
+
+// GetAllWlans returns all WLANs linked to this [Site].
+// It will return an error if it fails to fetch the WLANs.
+func (site *Site) GetAllWlans() (WlanResponse, error) {
+	return site.GetAllWlansWithContext(context.Background())
+}
+
+// GetAllWlansWithContext is the same as [Site.GetAllWlans] but uses the given context for the
+// request.
+func (site *Site) GetAllWlansWithContext(ctx context.Context) (WlanResponse, error) {
+	return wlanResource.getAll(ctx, site)
+}
+
+// GetWlan returns the WLAN linked to the given ID and this [Site].
+// It will return an error if it fails to fetch the specific WLAN, however if no WLAN with the
+// given ID is present or the ID is invalid no error but a response with an empty data array will
+// be returned.
+func (site *Site) GetWlan(id string) (WlanResponse, error) {
+	return site.GetWlanWithContext(context.Background(), id)
+}
+
+// GetWlanWithContext is the same as [Site.GetWlan] but uses the given context for the request.
+func (site *Site) GetWlanWithContext(ctx context.Context, id string) (WlanResponse, error) {
+	return wlanResource.get(ctx, site, id)
+}
+
+// UpdateWlan updates the WLAN linked to the given ID and this [Site] using the given WLAN data.
+// It will return an error if the update of the WLAN failed.
+func (site *Site) UpdateWlan(id string, wlan Wlan) (WlanResponse, error) {
+	return site.UpdateWlanWithContext(context.Background(), id, wlan)
+}
+
+// UpdateWlanWithContext is the same as [Site.UpdateWlan] but uses the given context for the
+// request.
+func (site *Site) UpdateWlanWithContext(
+	ctx context.Context,
+	id string,
+	wlan Wlan,
+) (WlanResponse, error) {
+	return wlanResource.update(ctx, site, id, wlan)
+}
+
+// EnableWlan enables the WLAN linked to the given ID and this [Site].
+// It will return an error if the update of the WLAN failed.
+func (site *Site) EnableWlan(id string) (WlanResponse, error) {
+	return site.EnableWlanWithContext(context.Background(), id)
+}
+
+// EnableWlanWithContext is the same as [Site.EnableWlan] but uses the given context for the
+// request.
+func (site *Site) EnableWlanWithContext(ctx context.Context, id string) (WlanResponse, error) {
+	return wlanResource.updateFields(ctx, site, id, map[string]bool{"enabled": true})
+}
+
+// DisableWlan disables the WLAN linked to the given ID and this [Site].
+// It will return an error if the update of the WLAN failed.
+func (site *Site) DisableWlan(id string) (WlanResponse, error) {
+	return site.DisableWlanWithContext(context.Background(), id)
+}
+
+// DisableWlanWithContext is the same as [Site.DisableWlan] but uses the given context for the
+// request.
+func (site *Site) DisableWlanWithContext(ctx context.Context, id string) (WlanResponse, error) {
+	return wlanResource.updateFields(ctx, site, id, map[string]bool{"enabled": false})
+}
+
+// DeleteWlan deletes the WLAN linked to the given ID and this [Site].
+// It will return an error if the deletion of the WLAN failed.
+func (site *Site) DeleteWlan(id string) (WlanResponse, error) {
+	return site.DeleteWlanWithContext(context.Background(), id)
+}
+
+// DeleteWlanWithContext is the same as [Site.DeleteWlan] but uses the given context for the
+// request.
+func (site *Site) DeleteWlanWithContext(ctx context.Context, id string) (WlanResponse, error) {
+	return wlanResource.delete(ctx, site, id)
+}